@@ -0,0 +1,171 @@
+package main
+
+import (
+  "os"
+  "errors"
+  "context"
+  "strings"
+  "log/slog"
+
+  "github.com/NataRich/auto-case/court"
+)
+
+// 批量新建：依次处理各数据源中选出的行，显示进度并返回运行汇总；
+// 配置了提交时间安排时按处理记录中的每日新建数控制提交；选行失败时汇总为空
+func RunBatch(conf *GlobalConfig, cases *CaseSet, sources []*DataConfig, control *Control, ledger *Ledger) (*Summary, error) {
+  submitter, err := NewSubmitter(conf, ledger)
+  if err != nil {
+    return nil, err
+  }
+
+  // 提交前先按指定行号与筛选表达式确定各数据源要处理的行
+  var labels []string
+  selected := make([][]int, len(sources))
+  total := 0
+  for i, src := range sources {
+    lines, err := SelectRows(src)
+    if err != nil {
+      return nil, err
+    }
+    selected[i] = lines
+    total += len(selected[i])
+    labels = append(labels, src.Label())
+  }
+
+  summary := NewSummary(strings.Join(labels, T("list.sep")))
+
+  progress := NewProgress(os.Stdout, summary, total)
+  console.SetProgress(progress)
+
+  submitRow := func(src *DataConfig, line int, row []string, rowErr error) error {
+    if err := control.Wait(); err != nil {
+      return err
+    }
+
+    progress.Start(line)
+    defer progress.Update()
+
+    lg := RowLogger(src, line)
+    if len(sources) > 1 {
+      lg = lg.With("path", src.Path)
+    }
+
+    if rowErr != nil {
+      summary.Skip(line, rowErr.Error())
+      return nil
+    }
+
+    ca, issues := PrepareRow(cases, src, row, line)
+    var appName, resName string
+    if ca != nil {
+      appName, resName = ca.DefaultApplicant.Name, ca.DefaultRespondent.Name
+      lg = lg.With("applicant", appName)
+      lg.Info(T("log.rowLoaded"), "respondent", resName)
+    }
+
+    issues.Log(lg)
+    if issues.HasError() {
+      lg.Warn(T("log.rowInvalid"))
+      var reasons []string
+      for _, issue := range issues {
+        if issue.Severity == SEVERITY_ERROR {
+          reasons = append(reasons, issue.Message)
+        }
+      }
+      summary.Reject(line, appName, resName, strings.Join(reasons, T("list.sep")))
+      return nil
+    }
+
+    res, err := submitter.Wait(control.Context(), ca, lg)
+    if err != nil {
+      return err
+    }
+
+    err = submitter.Submit(control.Context(), ca, res, lg)
+    summary.Submit(line, appName, resName, err)
+
+    // 会话失效或结果未知时停止，以免后续各行重复失败或重复新建
+    if errors.Is(err, court.ErrSessionExpired) || errors.Is(err, court.ErrUnknownResult) {
+      return err
+    }
+
+    if err != nil {
+      lg.Error(T("log.rowFailed"), "retry", conf.Request.Retry, "err", err)
+    }
+    return nil
+  }
+
+  for i, src := range sources {
+    if len(sources) > 1 {
+      summary.At(src.Label())
+      Logger.Info(T("log.source"), "path", src.Path, "sheet", src.Sheet, "rows", len(selected[i]))
+    }
+
+    err = EachRowIn(src, selected[i], func(line int, row []string, rowErr error) error {
+      return submitRow(src, line, row, rowErr)
+    })
+    if err != nil {
+      break
+    }
+  }
+
+  if err == ErrInterrupted {
+    summary.Interrupted = true
+  }
+
+  if err == nil && summary.Failed + summary.Invalid > 0 {
+    err = ErrPartialFailure
+  }
+  summary.ExitCode = ExitCode(err)
+
+  console.SetProgress(nil)
+  progress.Finish()
+
+  summary.Finish()
+  return summary, err
+}
+
+// 案件提交：批量新建、监视目录与接口服务共用
+type Submitter struct {
+  client      *court.Client
+  scheduler   *Scheduler
+}
+
+// 由配置生成案件提交，伪请求不受提交时间安排限制
+func NewSubmitter(conf *GlobalConfig, ledger *Ledger) (*Submitter, error) {
+  s := &Submitter{ client: NewClient(conf.Request, conf.Debug) }
+  if conf.Debug == nil || !conf.Debug.Fake {
+    scheduler, err := NewScheduler(conf.Schedule, ledger)
+    if err != nil {
+      return nil, err
+    }
+    s.scheduler = scheduler
+  }
+
+  return s, nil
+}
+
+// 提交前调用：按提交时间安排等待并预留每日新建数，中断时返回ErrInterrupted
+func (s *Submitter) Wait(ctx context.Context, ca *CaseConfig, lg *slog.Logger) (*Reservation, error) {
+  if s.scheduler == nil {
+    return nil, nil
+  }
+  return s.scheduler.Wait(ctx, ca.DefaultMediatorId, lg)
+}
+
+// 生成调解日期并发送新建请求（失败时重试），明确失败时释放预留的新建数
+// （新建成功或结果未知时可能已新建，仍计入每日新建数）
+func (s *Submitter) Submit(ctx context.Context, ca *CaseConfig, res *Reservation, lg *slog.Logger) error {
+  InsertRandomDates(ca)
+
+  lg.Info(T("log.sending"))
+  err := s.client.WithLogger(lg).SubmitWithRetry(ctx, court.BuildCaseBody(ca))
+
+  if s.scheduler != nil && err != nil && !errors.Is(err, court.ErrUnknownResult) {
+    if err := s.scheduler.Release(res); err != nil {
+      lg.Error(T("log.quotaFailed"), "err", err)
+    }
+  }
+
+  return err
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+  "strings"
+)
+
+// 代码表中的一项
+type Code struct {
+  Value       string
+  Label       string
+}
+
+// 代码表
+type CodeTable []Code
+
+// 按代码或名称查找，返回代码
+func (t CodeTable) Lookup(s string) (string, bool) {
+  s = strings.TrimSpace(s)
+  for _, c := range t {
+    if s == c.Value || s == c.Label {
+      return c.Value, true
+    }
+  }
+
+  return "", false
+}
+
+// 性别代码（GB/T 2261.1）
+var SexCodes = CodeTable{
+  { "1", "男" },
+  { "2", "女" },
+}
+
+// 民族代码（GB/T 3304）
+var NationCodes = CodeTable{
+  { "01", "汉族" },       { "02", "蒙古族" },     { "03", "回族" },       { "04", "藏族" },
+  { "05", "维吾尔族" },   { "06", "苗族" },       { "07", "彝族" },       { "08", "壮族" },
+  { "09", "布依族" },     { "10", "朝鲜族" },     { "11", "满族" },       { "12", "侗族" },
+  { "13", "瑶族" },       { "14", "白族" },       { "15", "土家族" },     { "16", "哈尼族" },
+  { "17", "哈萨克族" },   { "18", "傣族" },       { "19", "黎族" },       { "20", "傈僳族" },
+  { "21", "佤族" },       { "22", "畲族" },       { "23", "高山族" },     { "24", "拉祜族" },
+  { "25", "水族" },       { "26", "东乡族" },     { "27", "纳西族" },     { "28", "景颇族" },
+  { "29", "柯尔克孜族" }, { "30", "土族" },       { "31", "达斡尔族" },   { "32", "仫佬族" },
+  { "33", "羌族" },       { "34", "布朗族" },     { "35", "撒拉族" },     { "36", "毛南族" },
+  { "37", "仡佬族" },     { "38", "锡伯族" },     { "39", "阿昌族" },     { "40", "普米族" },
+  { "41", "塔吉克族" },   { "42", "怒族" },       { "43", "乌孜别克族" }, { "44", "俄罗斯族" },
+  { "45", "鄂温克族" },   { "46", "德昂族" },     { "47", "保安族" },     { "48", "裕固族" },
+  { "49", "京族" },       { "50", "塔塔尔族" },   { "51", "独龙族" },     { "52", "鄂伦春族" },
+  { "53", "赫哲族" },     { "54", "门巴族" },     { "55", "珞巴族" },     { "56", "基诺族" },
+}
+
+// 日志格式
+var LogFormats = CodeTable{
+  { LOG_TEXT, "文本" },
+  { LOG_JSON, "JSON" },
+}
+
+// 日志级别
+var LogLevels = CodeTable{
+  { "debug", "调试" },
+  { "info",  "信息" },
+  { "warn",  "警告" },
+  { "error", "错误" },
+}
+
+// 各字段适用的代码表（字段名同json标签）
+var fieldCodes = map[string]CodeTable{
+  "sex":       SexCodes,
+  "nation":    NationCodes,
+  "logFormat": LogFormats,
+  "logLevel":  LogLevels,
+}
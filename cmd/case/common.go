@@ -0,0 +1,73 @@
+package main
+
+import (
+  "os"
+  "fmt"
+  "time"
+  "math/rand"
+  "io/ioutil"
+  "path/filepath"
+)
+
+func NowYearStr() string {
+  return fmt.Sprintf("%d", time.Now().Year())
+}
+
+func DeltaDayStr(delta int, base time.Time) string {
+  base = base.AddDate(0, 0, delta)
+  return string(base.Format("2006-01-02 15:04:05"))
+}
+
+// 随机生成调解起止时间：结束于1至10天前，开始于结束前1至10天
+func InsertRandomDates(conf *CaseConfig) {
+  delta := rand.Intn(10) + 1
+  endDelta := rand.Intn(10) + 1
+
+  now := time.Now()
+  conf.StartTime = DeltaDayStr(-(endDelta + delta), now)
+  conf.EndTime = DeltaDayStr(-endDelta, now)
+
+  Logger.Debug(T("log.dates"), "startTime", conf.StartTime, "endTime", conf.EndTime)
+}
+
+// 备份文件为“原文件名.时间戳.bak”，返回备份路径
+func BackupFile(path string) (string, error) {
+  data, err := ioutil.ReadFile(path)
+  if err != nil {
+    return "", err
+  }
+
+  stamp := time.Now().Format("20060102-150405")
+  backup := fmt.Sprintf("%s.%s.bak", path, stamp)
+  for i := 1; ; i++ {
+    if _, err := os.Stat(backup); os.IsNotExist(err) {
+      break
+    }
+    backup = fmt.Sprintf("%s.%s-%d.bak", path, stamp, i)
+  }
+
+  if err := ioutil.WriteFile(backup, data, 0600); err != nil {
+    return "", err
+  }
+
+  return backup, nil
+}
+
+// 两个路径是否指向同一文件
+func sameFile(a string, b string) bool {
+  if a == "" || b == "" {
+    return false
+  }
+
+  x, err := filepath.Abs(a)
+  if err != nil {
+    return false
+  }
+
+  y, err := filepath.Abs(b)
+  if err != nil {
+    return false
+  }
+
+  return x == y
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+  "time"
+  "testing"
+)
+
+func TestInsertRandomDates(t *testing.T) {
+  const layout = "2006-01-02 15:04:05"
+  now := time.Now()
+
+  for i := 0; i < 100; i++ {
+    ca := &CaseConfig{}
+    InsertRandomDates(ca)
+
+    start, err := time.ParseInLocation(layout, ca.StartTime, time.Local)
+    if err != nil {
+      t.Fatalf("startTime %q: %v", ca.StartTime, err)
+    }
+
+    end, err := time.ParseInLocation(layout, ca.EndTime, time.Local)
+    if err != nil {
+      t.Fatalf("endTime %q: %v", ca.EndTime, err)
+    }
+
+    days := end.Sub(start).Hours() / 24
+    if days < 1 || days > 10.5 {
+      t.Errorf("start %s, end %s: %.1f days apart", ca.StartTime, ca.EndTime, days)
+    }
+
+    ago := now.Sub(end).Hours() / 24
+    if ago < 0.5 || ago > 10.5 {
+      t.Errorf("end %s is %.1f days before now", ca.EndTime, ago)
+    }
+  }
+}
@@ -0,0 +1,640 @@
+package main
+
+import (
+  "os"
+  "fmt"
+  "errors"
+  "strings"
+  "io/ioutil"
+  "encoding/json"
+
+  "github.com/xuri/excelize/v2"
+
+  "github.com/NataRich/auto-case/court"
+)
+
+// 当事人与案件配置定义在court包中
+type PersonConfig = court.PersonConfig
+type CaseConfig = court.CaseConfig
+
+// 数据源配置
+type DataConfig struct {
+  // 数据Excel表或CSV文件
+  Path          string                `json:"path"`
+
+  // 工作表名
+  Sheet         string                `json:"sheet"`
+
+  // 跳过列名行
+  SkipHeader    bool                  `json:"skipHeader"`
+
+  // 跳过行数
+  SkipLines     int                   `json:"skipLines"`
+
+  // 执行行数（有筛选表达式时为满足条件的行数）
+  ExecCount     int                   `json:"execCount"`
+
+  // 指定行号，如 17,42,90-95（优先于跳过列名行、跳过行数与截止行数）
+  Rows          string                `json:"rows,omitempty"`
+
+  // 行筛选表达式，如 F > 5000 && G == "劳动争议"
+  Where         string                `json:"where,omitempty"`
+
+  // 申请人列号
+  ApplicantCol  string                `json:"applicantCol"`
+
+  // 被申请人列号
+  RespondentCol string                `json:"respondentCol"`
+
+  // 配置方案列号，单元格的值为该行使用的配置方案名称（仅使用方案中的case配置）
+  ProfileCol    string                `json:"profileCol"`
+
+  // 自定义配置（当事人字段 -> 列号），如 "applicant.tel": "C"
+  Mapper        map[string]string     `json:"mapper"`
+
+  // 多个数据源，依次处理并汇总；每项继承以上配置并可覆盖，path可用通配符如 incoming/*.xlsx，sheet为空时处理所有工作表
+  Sources       []json.RawMessage     `json:"sources,omitempty"`
+}
+
+// 请求配置
+type RequestConfig struct {
+  // 单次请求延迟（秒）
+  Delay       int             `json:"delay"`
+
+  // 单次请求重试次数
+  Retry       int             `json:"retry"`
+
+  // 单次请求超时时长（秒）
+  Timeout     int             `json:"timeout"`
+
+  // 浏览器中的完整cookie字符串（不会被保存回配置文件）
+  Cookie      string          `json:"cookie"`
+
+  // cookie文件路径（"-"表示从标准输入读取），优先于cookie
+  CookieFile  string          `json:"cookieFile"`
+
+  // 新建案例接口地址，为空时使用调解平台地址（仅用于测试）
+  Endpoint    string          `json:"endpoint,omitempty"`
+}
+
+// 调试配置
+type DebugConfig struct {
+  // 调试模式
+  Verbose     bool            `json:"verbose"`
+
+  // 伪请求模式
+  Fake        bool            `json:"fake"`
+
+  // 错误日志文件
+  LogPath     string          `json:"logPath"`
+
+  // 日志格式：text 或 json
+  LogFormat   string          `json:"logFormat"`
+
+  // 写入日志文件的最低级别：debug、info、warn 或 error
+  LogLevel    string          `json:"logLevel"`
+
+  // 日志文件轮转：单个文件上限（MB）、保留旧文件个数、保留天数
+  LogMaxSize    int           `json:"logMaxSize"`
+  LogMaxBackups int           `json:"logMaxBackups"`
+  LogMaxAge     int           `json:"logMaxAge"`
+}
+
+// 个人信息遮盖策略，作用于控制台、日志、预览与运行报告；未配置时全部遮盖
+type MaskConfig struct {
+  // 遮盖当事人姓名，如 张*三
+  Names       bool            `json:"names"`
+
+  // 遮盖手机号与固定电话，如 138****1234
+  Phones      bool            `json:"phones"`
+
+  // 遮盖证件号码，如 110101********1234
+  IDCards     bool            `json:"idCards"`
+
+  // 遮盖地址，只保留前6个字
+  Addresses   bool            `json:"addresses"`
+
+  // 以摘要代替cookie
+  Cookies     bool            `json:"cookies"`
+}
+
+// 监视目录配置（case watch）
+type WatchConfig struct {
+  // 扫描目录的间隔（秒）
+  Interval    int               `json:"interval"`
+
+  // 文件大小保持不变多久后才处理（秒），以免处理尚未复制完成的文件
+  Settle      int               `json:"settle"`
+
+  // 按文件名选择配置方案（通配符 -> 方案名称），如 "labor-*.xlsx": "labor"；未匹配的文件使用基础配置
+  Profiles    map[string]string `json:"profiles,omitempty"`
+}
+
+// 提交时间安排：只在允许的时间段内提交并限制每天新建的案件数，否则等待（已新建数记录在处理记录中）
+type ScheduleConfig struct {
+  // 允许提交的时间段（本地时间），如 ["09:00-11:30", "14:00-17:00"]；为空时全天允许
+  Windows             []string        `json:"windows,omitempty"`
+
+  // 允许提交的星期（1为周一，7为周日），为空时每天允许
+  Weekdays            []int           `json:"weekdays,omitempty"`
+
+  // 账号名称，用于按账号统计每天的新建数，多个配置文件使用同一账号时应填写相同名称
+  Account             string          `json:"account"`
+
+  // 每个账号每天最多新建的案件数，0为不限
+  DailyLimit          int             `json:"dailyLimit"`
+
+  // 每个调解员每天最多新建的案件数，0为不限
+  MediatorDailyLimit  int             `json:"mediatorDailyLimit"`
+}
+
+// 接口服务配置（case serve）
+type ServeConfig struct {
+  // 监听地址，为空时为127.0.0.1:8765（只接受本机访问）
+  Addr        string          `json:"addr"`
+
+  // 访问令牌，请求头为 Authorization: Bearer 令牌；不会写回配置文件，请用环境变量CASE_SERVE_TOKEN设置
+  Token       string          `json:"token"`
+
+  // 排队等待提交的案件上限，0为默认值100
+  QueueSize   int             `json:"queueSize"`
+}
+
+// 全局配置
+type GlobalConfig struct {
+  // 配置文件版本
+  Version     int             `json:"version"`
+
+  Case        *CaseConfig     `json:"case"`
+  Data        *DataConfig     `json:"data"`
+  Request     *RequestConfig  `json:"request"`
+  Debug       *DebugConfig    `json:"debug"`
+  Mask        *MaskConfig     `json:"mask,omitempty"`
+  Watch       *WatchConfig    `json:"watch,omitempty"`
+  Schedule    *ScheduleConfig `json:"schedule,omitempty"`
+  Serve       *ServeConfig    `json:"serve,omitempty"`
+
+  // 处理记录文件，记录已处理的工作簿，保证同一文件不会被重复处理
+  Ledger      string          `json:"ledger,omitempty"`
+
+  // 配置方案（名称 -> 覆盖项），继承并覆盖以上基础配置
+  Profiles    map[string]json.RawMessage  `json:"profiles,omitempty"`
+
+  // 加载配置文件时发现的问题（未知或已弃用的配置项）
+  warnings    Issues
+}
+
+var Conf *GlobalConfig
+
+// 默认配置
+func InitConf() (*GlobalConfig) {
+  return &GlobalConfig{
+    Version:  CONFIG_VERSION,
+
+    Case:     &CaseConfig{
+      Type:               "0",
+      Year:               NowYearStr(),
+      CaseCatalog:        "",
+      DisputeType:        "",
+      CauseCode:          "",
+      State:              "",
+      SuccessState:       "",
+      StartTime:          "",
+      EndTime:            "",
+      Dispute:            "",
+      Agreement:          "",
+      AutoCreate:         "1",
+      DefaultMediatorId:  "",
+      DefaultApplicant:   &PersonConfig{
+        Type:             "",
+        Name:             "",
+        Tel:              "",
+        StaticPhone:      "",
+        CredentialsType:  "",
+        IDCardNo:         "",
+        Sex:              "",
+        Birthday:         "",
+        Nation:           "",
+        AreaCode:         "",
+        Address:          "",
+      },
+      DefaultRespondent:  &PersonConfig{
+        Type:             "",
+        Name:             "",
+        Tel:              "",
+        StaticPhone:      "",
+        CredentialsType:  "",
+        IDCardNo:         "",
+        Sex:              "",
+        Birthday:         "",
+        Nation:           "",
+        AreaCode:         "",
+        Address:          "",
+      },
+    },
+
+    Data:     &DataConfig{
+      Path:               "",
+      Sheet:              "",
+      SkipHeader:         false,
+      SkipLines:          0,
+      ExecCount:          1,
+      ApplicantCol:       "",
+      RespondentCol:      "",
+      ProfileCol:         "",
+      Mapper:             map[string]string{},
+    },
+
+    Request:  &RequestConfig{
+      Delay:              2,
+      Retry:              3,
+      Timeout:            10,
+      Cookie:             "",
+      CookieFile:         "",
+    },
+
+    Debug:    &DebugConfig{
+      Verbose:            true,
+      Fake:               false,
+      LogPath:            "error.log",
+      LogFormat:          LOG_TEXT,
+      LogLevel:           "warn",
+      LogMaxSize:         10,
+      LogMaxBackups:      5,
+      LogMaxAge:          30,
+    },
+
+    Mask:     DefaultMask(),
+
+    Watch:    DefaultWatch(),
+    Serve:    DefaultServe(),
+    Ledger:   LEDGER_FILE,
+  }
+}
+
+// 从文件加载配置（按扩展名支持JSON、YAML、TOML）
+func LoadConf(path string) (*GlobalConfig, error) {
+  if _, err := os.Stat(path); err != nil {
+    return nil, err
+  }
+
+  bytes, err := ioutil.ReadFile(path)
+  if err != nil {
+    return nil, err
+  }
+
+  bytes, err = DecodeConf(path, bytes)
+  if err != nil {
+    return nil, err
+  }
+
+  bytes, warnings, _, err := loadConfDoc(path, bytes)
+  if err != nil {
+    return nil, err
+  }
+
+  Conf = nil
+  if err := json.Unmarshal(bytes, &Conf); err != nil {
+    return nil, confError(path, err)
+  }
+
+  if Conf == nil {
+    return nil, NewError("err.confEmpty", path)
+  }
+
+  Conf.warnings = warnings
+  return Conf, nil
+}
+
+// 将已有配置文件的值合并到conf上，文件中没有的配置项保留conf中的值；
+// 旧版本配置在内存中升级，不改写该文件，返回其升级前的版本
+func MergeConf(conf *GlobalConfig, path string) (int, error) {
+  bytes, err := ioutil.ReadFile(path)
+  if err != nil {
+    return 0, err
+  }
+
+  bytes, err = DecodeConf(path, bytes)
+  if err != nil {
+    return 0, err
+  }
+
+  bytes, _, version, err := loadConfDoc(path, bytes)
+  if err != nil {
+    return version, err
+  }
+
+  if err := json.Unmarshal(bytes, conf); err != nil {
+    return version, confError(path, err)
+  }
+
+  conf.Version = CONFIG_VERSION
+  return version, nil
+}
+
+// 配置项类型错误时指出配置项及应有的类型
+func confError(path string, err error) error {
+  var te *json.UnmarshalTypeError
+  if errors.As(err, &te) && te.Field != "" {
+    return NewError("err.confType", path, te.Field, te.Type)
+  }
+
+  return err
+}
+
+// 读取单元格，超出行长度时视为空
+func cellAt(row []string, col int) string {
+  if col < 1 || col > len(row) {
+    return ""
+  }
+
+  return strings.TrimSpace(row[col - 1])
+}
+
+// 用excel行数据填充案件配置（姓名及自定义映射），返回字段对应的单元格
+func FillRow(ca *CaseConfig, data *DataConfig, row []string, line int) (map[string]string, error) {
+  if ca == nil || data == nil {
+    return nil, NewError("err.fillRow")
+  }
+
+  return CourtLocale.FillRow(ca, data.Columns(), row, line)
+}
+
+// 当事人字段到列名的映射（姓名列及自定义映射）
+func (data *DataConfig) Columns() map[string]string {
+  cols := map[string]string{
+    "applicant.name":   data.ApplicantCol,
+    "respondent.name":  data.RespondentCol,
+  }
+  for key, col := range data.Mapper {
+    cols[key] = col
+  }
+
+  return cols
+}
+
+// 保存配置到文件（按扩展名支持JSON、YAML、TOML）
+func SaveConf(path string) error {
+  if Conf == nil {
+    return NewError("err.notLoaded")
+  }
+
+  // 敏感信息不写回配置文件
+  conf := *Conf
+  if conf.Request != nil {
+    req := *conf.Request
+    req.Cookie = ""
+    conf.Request = &req
+  }
+
+  if conf.Serve != nil {
+    serve := *conf.Serve
+    serve.Token = ""
+    conf.Serve = &serve
+  }
+
+  data, err := json.MarshalIndent(&conf, "", "  ")
+  if err != nil {
+    return err
+  }
+
+  data, err = EncodeConf(path, data)
+  if err != nil {
+    return err
+  }
+
+  if err := ioutil.WriteFile(path, data, 0644); err != nil {
+    return err
+  }
+
+  return nil
+}
+
+// 预先配置检查，收集所有问题
+func PreCheck(conf *GlobalConfig) Issues {
+  var issues Issues
+  if conf == nil {
+    issues.Error("", "CONF_EMPTY")
+    return issues
+  }
+
+  issues = append(issues, conf.warnings...)
+
+  // 案件配置检查（按行选择配置方案时，基础案件配置只影响未指定方案的行）
+  ca := conf.Case
+  if conf.Data != nil && conf.Data.UsesProfiles() {
+    for _, issue := range CaseCheck(ca, "case") {
+      issue.Severity = SEVERITY_WARNING
+      issues = append(issues, issue)
+    }
+
+    if len(conf.Profiles) == 0 {
+      issues.Error("data.profileCol", "PROFILE_COL_WITHOUT_PROFILES")
+    }
+
+    for _, name := range conf.ProfileNames() {
+      pc, err := ApplyProfile(conf, name)
+      if err != nil {
+        issues.Error("profiles." + name, "PROFILE_INVALID", err)
+        continue
+      }
+      issues = append(issues, CaseCheck(pc.Case, "profiles." + name + ".case")...)
+    }
+  } else {
+    issues = append(issues, CaseCheck(ca, "case")...)
+  }
+
+  // 数据源配置检查
+  data := conf.Data
+  if data == nil {
+    issues.Error("data", "DATA_EMPTY")
+  } else if len(data.Sources) == 0 {
+    issues = append(issues, DataCheck(data, ca, "data")...)
+  } else if sources, err := data.List(); err != nil {
+    issues.Error("data.sources", "DATA_SOURCE_INVALID", err)
+  } else {
+    for i, src := range sources {
+      issues = append(issues, DataCheck(src, ca, fmt.Sprintf("data.sources[%d]", i))...)
+    }
+  }
+
+  // 请求配置检查
+  req := conf.Request
+  if req == nil {
+    issues.Error("request", "REQUEST_EMPTY")
+  } else {
+    if req.Delay < 0 {
+      issues.Error("request.delay", "REQUEST_DELAY_NEGATIVE")
+    }
+
+    if req.Retry < 0 {
+      issues.Error("request.retry", "REQUEST_RETRY_NEGATIVE")
+    }
+
+    if req.Timeout < 0 {
+      issues.Error("request.timeout", "REQUEST_TIMEOUT_NEGATIVE")
+    }
+
+    if req.Cookie == "" {
+      issues.Error("request.cookie", "REQUEST_COOKIE_EMPTY")
+    }
+  }
+
+  if conf.Schedule != nil {
+    issues = append(issues, ScheduleCheck(conf.Schedule)...)
+  }
+
+  // 调试配置检查
+  debug := conf.Debug
+  if debug == nil {
+    issues.Error("debug", "DEBUG_EMPTY")
+  } else {
+    if debug.LogPath == "" {
+      issues.Warn("debug.logPath", "DEBUG_LOG_PATH_EMPTY")
+    }
+
+    if debug.LogFormat != "" && debug.LogFormat != LOG_TEXT && debug.LogFormat != LOG_JSON {
+      issues.Error("debug.logFormat", "DEBUG_LOG_FORMAT_INVALID")
+    }
+
+    if _, err := ParseLogLevel(debug.LogLevel); err != nil {
+      issues.Error("debug.logLevel", "DEBUG_LOG_LEVEL_INVALID", err)
+    }
+  }
+
+  return issues
+}
+
+// 数据源配置检查，path为字段路径前缀（如 data、data.sources[0]）；
+// sources中的数据源与CSV文件可不指定工作表
+func DataCheck(data *DataConfig, ca *CaseConfig, path string) Issues {
+  var issues Issues
+  if data.Path == "" {
+    issues.Error(path + ".path", "DATA_PATH_EMPTY")
+  }
+
+  if data.Sheet == "" && path == "data" && !isCSV(data.Path) {
+    issues.Error(path + ".sheet", "DATA_SHEET_EMPTY")
+  }
+
+  if data.SkipLines < 0 {
+    issues.Error(path + ".skipLines", "DATA_SKIP_NEGATIVE")
+  }
+
+  if data.ExecCount <= 0 && data.Rows == "" {
+    issues.Error(path + ".execCount", "DATA_COUNT_INVALID")
+  }
+
+  if data.Rows != "" {
+    if _, err := ParseRowSet(data.Rows); err != nil {
+      issues.Error(path + ".rows", "DATA_ROWS_INVALID", err)
+    }
+  }
+
+  if data.Where != "" {
+    if _, err := ParseFilter(data.Where); err != nil {
+      issues.Error(path + ".where", "DATA_WHERE_INVALID", err)
+    }
+  }
+
+  if data.ApplicantCol == "" {
+    issues.Error(path + ".applicantCol", "DATA_APPLICANT_COL_EMPTY")
+  } else if _, err := excelize.ColumnNameToNumber(data.ApplicantCol); err != nil {
+    issues.Error(path + ".applicantCol", "DATA_APPLICANT_COL_INVALID", data.ApplicantCol)
+  }
+
+  if data.RespondentCol == "" {
+    issues.Error(path + ".respondentCol", "DATA_RESPONDENT_COL_EMPTY")
+  } else if _, err := excelize.ColumnNameToNumber(data.RespondentCol); err != nil {
+    issues.Error(path + ".respondentCol", "DATA_RESPONDENT_COL_INVALID", data.RespondentCol)
+  }
+
+  if data.ProfileCol != "" {
+    if _, err := excelize.ColumnNameToNumber(data.ProfileCol); err != nil {
+      issues.Error(path + ".profileCol", "DATA_PROFILE_COL_INVALID", data.ProfileCol)
+    }
+  }
+
+  for key, col := range data.Mapper {
+    p := path + ".mapper." + key
+    if ca != nil {
+      if _, err := CourtLocale.PartyField(ca, key); err != nil {
+        issues.Error(p, "DATA_MAPPER_INVALID", err)
+      }
+    }
+
+    if _, err := excelize.ColumnNameToNumber(col); err != nil {
+      issues.Error(p, "DATA_MAPPER_COL_INVALID", key, col)
+    }
+  }
+
+  return issues
+}
+
+// 案件配置检查，path为字段路径前缀（如 case）
+func CaseCheck(ca *CaseConfig, path string) Issues {
+  var issues Issues
+  if ca == nil {
+    issues.Error(path, "CASE_EMPTY")
+    return issues
+  }
+
+  if ca.Type == "" {
+    issues.Error(path + ".type", "CASE_TYPE_EMPTY")
+  }
+
+  if ca.Year == "" {
+    issues.Error(path + ".year", "CASE_YEAR_EMPTY")
+  }
+
+  if ca.CaseCatalog == "" {
+    issues.Error(path + ".caseCatalog", "CASE_CATALOG_EMPTY")
+  }
+
+  if ca.DisputeType == "" {
+    issues.Error(path + ".disputeType", "CASE_DISPUTE_TYPE_EMPTY")
+  }
+
+  if ca.CauseCode == "" {
+    issues.Error(path + ".causeCode", "CASE_CAUSE_EMPTY")
+  }
+
+  if ca.State == "" {
+    issues.Error(path + ".state", "CASE_STATE_EMPTY")
+  }
+
+  if ca.SuccessState == "" {
+    issues.Warn(path + ".successState", "CASE_SUCCESS_STATE_EMPTY")
+  }
+
+  if ca.Dispute == "" {
+    issues.Error(path + ".dispute", "CASE_DISPUTE_EMPTY")
+  }
+
+  if ca.Agreement == "" {
+    issues.Error(path + ".agreement", "CASE_AGREEMENT_EMPTY")
+  }
+
+  if ca.AutoCreate == "" {
+    issues.Warn(path + ".autoCreate", "CASE_AUTO_CREATE_EMPTY")
+  }
+
+  if ca.DefaultMediatorId == "" {
+    issues.Error(path + ".defaultMediatorId", "CASE_MEDIATOR_EMPTY")
+  }
+
+  if ca.DefaultApplicant == nil {
+    issues.Error(path + ".defaultApplicant", "CASE_APPLICANT_EMPTY")
+  }
+
+  if ca.DefaultRespondent == nil {
+    issues.Error(path + ".defaultRespondent", "CASE_RESPONDENT_EMPTY")
+  }
+
+  return issues
+}
+
+// 当事人配置检查，path为字段路径前缀（如 applicant）
+func PersonCheck(per *PersonConfig, path string) Issues {
+  return issuesOf(CourtLocale.CheckPerson(per, path))
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+  "os"
+  "regexp"
+  "strconv"
+  "strings"
+  "testing"
+  "path/filepath"
+)
+
+// 通过预先检查的完整配置
+func validConf() *GlobalConfig {
+  conf := InitConf()
+  conf.Case.CaseCatalog = "1"
+  conf.Case.DisputeType = "2"
+  conf.Case.CauseCode = "3"
+  conf.Case.State = "4"
+  conf.Case.SuccessState = "1"
+  conf.Case.Dispute = "纠纷概况"
+  conf.Case.Agreement = "调解方案"
+  conf.Case.DefaultMediatorId = "m1"
+  conf.Case.DefaultApplicant = validPerson()
+  conf.Case.DefaultRespondent = validPerson()
+
+  conf.Data.Path = "data.xlsx"
+  conf.Data.Sheet = "Sheet1"
+  conf.Data.ApplicantCol = "A"
+  conf.Data.RespondentCol = "B"
+  conf.Request.Cookie = "JSESSIONID=abc"
+  return conf
+}
+
+func validPerson() *PersonConfig {
+  return &PersonConfig{
+    Type:             "1",
+    Name:             "张三",
+    Tel:              "13800000000",
+    CredentialsType:  "1",
+    IDCardNo:         "110101199001011234",
+    Sex:              "1",
+    Birthday:         "1990-01-01",
+    Nation:           "01",
+    AreaCode:         "110101",
+    Address:          "北京市东城区",
+  }
+}
+
+// 问题编号与字段路径
+func codesOf(issues Issues) map[string]string {
+  codes := map[string]string{}
+  for _, issue := range issues {
+    codes[issue.Code] = issue.Path
+  }
+  return codes
+}
+
+func TestPreCheck(t *testing.T) {
+  tests := []struct {
+    name     string
+    modify   func(conf *GlobalConfig)
+    code     string
+    path     string
+    severity Severity
+  }{
+    { "no case", func(c *GlobalConfig) { c.Case = nil }, "CASE_EMPTY", "case", SEVERITY_ERROR },
+    { "no cause", func(c *GlobalConfig) { c.Case.CauseCode = "" }, "CASE_CAUSE_EMPTY", "case.causeCode", SEVERITY_ERROR },
+    { "no success state", func(c *GlobalConfig) { c.Case.SuccessState = "" }, "CASE_SUCCESS_STATE_EMPTY", "case.successState", SEVERITY_WARNING },
+    { "no data", func(c *GlobalConfig) { c.Data = nil }, "DATA_EMPTY", "data", SEVERITY_ERROR },
+    { "no sheet", func(c *GlobalConfig) { c.Data.Sheet = "" }, "DATA_SHEET_EMPTY", "data.sheet", SEVERITY_ERROR },
+    { "negative skip", func(c *GlobalConfig) { c.Data.SkipLines = -1 }, "DATA_SKIP_NEGATIVE", "data.skipLines", SEVERITY_ERROR },
+    { "zero count", func(c *GlobalConfig) { c.Data.ExecCount = 0 }, "DATA_COUNT_INVALID", "data.execCount", SEVERITY_ERROR },
+    { "bad column", func(c *GlobalConfig) { c.Data.ApplicantCol = "1" }, "DATA_APPLICANT_COL_INVALID", "data.applicantCol", SEVERITY_ERROR },
+    { "bad mapper key", func(c *GlobalConfig) { c.Data.Mapper["applicant.foo"] = "C" }, "DATA_MAPPER_INVALID", "data.mapper.applicant.foo", SEVERITY_ERROR },
+    { "bad mapper column", func(c *GlobalConfig) { c.Data.Mapper["applicant.tel"] = "3" }, "DATA_MAPPER_COL_INVALID", "data.mapper.applicant.tel", SEVERITY_ERROR },
+    { "profile column without profiles", func(c *GlobalConfig) { c.Data.ProfileCol = "C" }, "PROFILE_COL_WITHOUT_PROFILES", "data.profileCol", SEVERITY_ERROR },
+    { "negative retry", func(c *GlobalConfig) { c.Request.Retry = -1 }, "REQUEST_RETRY_NEGATIVE", "request.retry", SEVERITY_ERROR },
+    { "no cookie", func(c *GlobalConfig) { c.Request.Cookie = "" }, "REQUEST_COOKIE_EMPTY", "request.cookie", SEVERITY_ERROR },
+    { "no log path", func(c *GlobalConfig) { c.Debug.LogPath = "" }, "DEBUG_LOG_PATH_EMPTY", "debug.logPath", SEVERITY_WARNING },
+    { "bad log format", func(c *GlobalConfig) { c.Debug.LogFormat = "xml" }, "DEBUG_LOG_FORMAT_INVALID", "debug.logFormat", SEVERITY_ERROR },
+    { "bad log level", func(c *GlobalConfig) { c.Debug.LogLevel = "trace" }, "DEBUG_LOG_LEVEL_INVALID", "debug.logLevel", SEVERITY_ERROR },
+  }
+
+  if issues := PreCheck(validConf()); len(issues) != 0 {
+    t.Fatalf("valid config has issues:\n%s", issues)
+  }
+
+  if codes := codesOf(PreCheck(nil)); len(codes) != 1 || codes["CONF_EMPTY"] != "" {
+    t.Errorf("nil config: %v", codes)
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      conf := validConf()
+      tt.modify(conf)
+
+      issues := PreCheck(conf)
+      if len(issues) != 1 {
+        t.Fatalf("got %d issues, want 1:\n%s", len(issues), issues)
+      }
+
+      issue := issues[0]
+      if issue.Code != tt.code || issue.Path != tt.path || issue.Severity != tt.severity {
+        t.Errorf("got %s %s %s, want %s %s %s",
+                 issue.Code, issue.Path, issue.Severity, tt.code, tt.path, tt.severity)
+      }
+    })
+  }
+}
+
+func TestPersonCheck(t *testing.T) {
+  tests := []struct {
+    name     string
+    modify   func(per *PersonConfig)
+    code     string
+    severity Severity
+  }{
+    { "no name", func(p *PersonConfig) { p.Name = "" }, "PARTY_NAME_EMPTY", SEVERITY_ERROR },
+    { "no tel", func(p *PersonConfig) { p.Tel = "" }, "PARTY_TEL_EMPTY", SEVERITY_ERROR },
+    { "no id card", func(p *PersonConfig) { p.IDCardNo = "" }, "PARTY_ID_CARD_EMPTY", SEVERITY_WARNING },
+    { "no nation", func(p *PersonConfig) { p.Nation = "" }, "PARTY_NATION_EMPTY", SEVERITY_ERROR },
+    { "no address", func(p *PersonConfig) { p.Address = "" }, "PARTY_ADDRESS_EMPTY", SEVERITY_ERROR },
+  }
+
+  if issues := PersonCheck(validPerson(), "applicant"); len(issues) != 0 {
+    t.Fatalf("valid person has issues:\n%s", issues)
+  }
+
+  if codes := codesOf(PersonCheck(nil, "respondent")); codes["PARTY_EMPTY"] != "respondent" {
+    t.Errorf("nil person: %v", codes)
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      per := validPerson()
+      tt.modify(per)
+
+      issues := PersonCheck(per, "respondent")
+      if len(issues) != 1 || issues[0].Code != tt.code || issues[0].Severity != tt.severity {
+        t.Errorf("got %v, want %s %s", codesOf(issues), tt.code, tt.severity)
+      }
+    })
+  }
+}
+
+func TestLoadConfScalars(t *testing.T) {
+  version := strconv.Itoa(CONFIG_VERSION)
+  files := map[string]string{
+    "config.yaml": `
+version: ` + version + `
+case:
+  type: 0
+  year: 2026
+  defaultApplicant:
+    nation: 01
+    tel: 13800001111
+data:
+  sheet: 2024
+  skipLines: 2
+  mapper:
+    applicant.tel: C
+  sources:
+    - sheet: 2025
+profiles:
+  labor:
+    case:
+      type: 1
+`,
+    "config.toml": `
+version = ` + version + `
+[case]
+type = 0
+year = 2026
+[case.defaultApplicant]
+nation = "01"
+tel = 13800001111
+[data]
+sheet = 2024
+skipLines = 2
+[[data.sources]]
+sheet = 2025
+[profiles.labor.case]
+type = 1
+`,
+  }
+
+  for name, text := range files {
+    path := filepath.Join(t.TempDir(), name)
+    if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+      t.Fatal(err)
+    }
+
+    conf, err := LoadConf(path)
+    if err != nil {
+      t.Fatalf("%s: %v", name, err)
+    }
+
+    app := conf.Case.DefaultApplicant
+    if conf.Case.Type != "0" || conf.Case.Year != "2026" || app.Nation != "01" || app.Tel != "13800001111" {
+      t.Errorf("%s: case %+v, applicant %+v", name, conf.Case, app)
+    }
+
+    if conf.Data.Sheet != "2024" || conf.Data.SkipLines != 2 || string(conf.Data.Sources[0]) != `{"sheet":"2025"}` {
+      t.Errorf("%s: data %+v", name, conf.Data)
+    }
+
+    if string(conf.Profiles["labor"]) != `{"case":{"type":"1"}}` {
+      t.Errorf("%s: profile %s", name, conf.Profiles["labor"])
+    }
+  }
+}
+
+// 保存时不写入cookie与访问令牌；保存为TOML时整数仍为整数（与case schema中的integer一致）
+func TestSaveConf(t *testing.T) {
+  Conf = validConf()
+  Conf.Data.ExecCount = 3
+  Conf.Request.Delay = 2
+  Conf.Serve = &ServeConfig{ Token: "s3cret-token", QueueSize: 100 }
+  path := filepath.Join(t.TempDir(), "config.toml")
+  if err := SaveConf(path); err != nil {
+    t.Fatal(err)
+  }
+
+  data, err := os.ReadFile(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  for _, secret := range []string{ Conf.Request.Cookie, Conf.Serve.Token } {
+    if strings.Contains(string(data), secret) {
+      t.Errorf("secret %q saved in:\n%s", secret, data)
+    }
+  }
+
+  if Conf.Request.Cookie == "" || Conf.Serve.Token == "" {
+    t.Error("SaveConf cleared the loaded secrets")
+  }
+
+  for _, line := range []string{ "version = " + strconv.Itoa(CONFIG_VERSION), "execCount = 3", "delay = 2", "queueSize = 100" } {
+    if !regexp.MustCompile(`(?m)^\s*` + regexp.QuoteMeta(line) + `$`).Match(data) {
+      t.Errorf("missing %q in:\n%s", line, data)
+    }
+  }
+
+  conf, err := LoadConf(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if conf.Version != CONFIG_VERSION || conf.Data.ExecCount != 3 || conf.Request.Delay != 2 || conf.Case.Type != Conf.Case.Type {
+    t.Errorf("round trip: %+v, data %+v", conf, conf.Data)
+  }
+}
+
+func TestMigrateConf(t *testing.T) {
+  dir := t.TempDir()
+  path := filepath.Join(dir, CONFIG_FILE)
+  old := `{"case":{"type":"0"},"request":{"cookie":"JSESSIONID=new"}}`
+  if err := os.WriteFile(path, []byte(old), 0644); err != nil {
+    t.Fatal(err)
+  }
+
+  // 另一个会话的cookie文件
+  other := filepath.Join(dir, "cookie.txt")
+  if err := os.WriteFile(other, []byte("JSESSIONID=other"), 0600); err != nil {
+    t.Fatal(err)
+  }
+
+  // 只读取时在内存中升级，不改写文件
+  conf, err := LoadConf(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if conf.Version != CONFIG_VERSION || conf.Request.Cookie != "JSESSIONID=new" || conf.Data == nil {
+    t.Errorf("migrated conf: version %d, request %+v", conf.Version, conf.Request)
+  }
+
+  if codes := codesOf(conf.warnings); codes["CONF_VERSION_OLD"] != "version" {
+    t.Errorf("warnings = %v, want CONF_VERSION_OLD", codes)
+  }
+
+  if data, _ := os.ReadFile(path); string(data) != old {
+    t.Errorf("config rewritten on load: %s", data)
+  }
+
+  if files, _ := filepath.Glob(filepath.Join(dir, "*")); len(files) != 2 {
+    t.Errorf("files after load: %v", files)
+  }
+
+  // case init原地升级，cookie移至新文件，不覆盖已有的cookie文件
+  if err := newApp().Run([]string{ "case", "--config", path, "init", "--from", path }); err != nil {
+    t.Fatal(err)
+  }
+
+  if data, _ := os.ReadFile(other); string(data) != "JSESSIONID=other" {
+    t.Errorf("existing cookie file overwritten: %s", data)
+  }
+
+  moved := filepath.Join(dir, "cookie-1.txt")
+  if data, _ := os.ReadFile(moved); string(data) != "JSESSIONID=new" {
+    t.Errorf("moved cookie = %q", data)
+  }
+
+  conf, err = LoadConf(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if conf.Request.Cookie != "" || conf.Request.CookieFile != moved || len(conf.warnings) != 0 {
+    t.Errorf("upgraded conf: request %+v, warnings %v", conf.Request, conf.warnings)
+  }
+}
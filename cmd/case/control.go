@@ -0,0 +1,149 @@
+package main
+
+import (
+  "os"
+  "sync"
+  "bufio"
+  "context"
+  "syscall"
+  "os/signal"
+
+  "golang.org/x/term"
+)
+
+// 批量新建被中断（Ctrl-C）
+var ErrInterrupted = NewError("err.interrupted")
+
+// 批量新建的运行控制：Ctrl-C 在当前请求完成后停止，再按一次立即退出；
+// 回车或暂停信号（SIGUSR1）暂停，再次触发时从暂停处继续
+type Control struct {
+  mu        sync.Mutex
+  paused    bool
+
+  // 继续时关闭
+  resume    chan struct{}
+
+  ctx       context.Context
+  cancel    context.CancelFunc
+  signals   chan os.Signal
+}
+
+// 新建运行控制并开始监听信号，interactive为真时同时监听终端中的回车
+func NewControl(parent context.Context, interactive bool) *Control {
+  ctx, cancel := context.WithCancel(parent)
+  c := &Control{
+    ctx:      ctx,
+    cancel:   cancel,
+    signals:  make(chan os.Signal, 1),
+  }
+
+  signal.Notify(c.signals, append([]os.Signal{ os.Interrupt, syscall.SIGTERM }, pauseSignals...)...)
+  go c.watchSignals()
+
+  if interactive {
+    go c.watchKeys()
+  }
+
+  return c
+}
+
+// 标准输入为终端时监听回车
+func StdinIsTerminal() bool {
+  return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// 中断时取消，用于请求间的等待；已发出的请求不受影响
+func (c *Control) Context() context.Context {
+  return c.ctx
+}
+
+// 停止监听信号
+func (c *Control) Stop() {
+  signal.Stop(c.signals)
+  close(c.signals)
+  c.cancel()
+}
+
+// 中断：当前行处理完成后停止
+func (c *Control) Interrupt() {
+  c.cancel()
+}
+
+// 是否已中断
+func (c *Control) Interrupted() bool {
+  return c.ctx.Err() != nil
+}
+
+// 切换暂停与继续
+func (c *Control) Toggle() {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  if c.paused {
+    c.paused = false
+    close(c.resume)
+    Logger.Warn(T("log.resumed"))
+    return
+  }
+
+  c.paused = true
+  c.resume = make(chan struct{})
+  Logger.Warn(T("log.paused"))
+}
+
+// 处理下一行前调用：暂停时等待继续，已中断时返回ErrInterrupted
+func (c *Control) Wait() error {
+  c.mu.Lock()
+  paused, resume := c.paused, c.resume
+  c.mu.Unlock()
+
+  if paused {
+    select {
+    case <-resume:
+    case <-c.ctx.Done():
+    }
+  }
+
+  if c.Interrupted() {
+    return ErrInterrupted
+  }
+
+  return nil
+}
+
+func (c *Control) watchSignals() {
+  for sig := range c.signals {
+    if isPauseSignal(sig) {
+      c.Toggle()
+      continue
+    }
+
+    if c.Interrupted() {
+      Logger.Error(T("log.forceExit"))
+      os.Exit(EXIT_INTERRUPTED)
+    }
+
+    Logger.Warn(T("log.interrupting"))
+    c.Interrupt()
+  }
+}
+
+func (c *Control) watchKeys() {
+  scanner := bufio.NewScanner(os.Stdin)
+  for scanner.Scan() {
+    if c.Interrupted() {
+      return
+    }
+    c.Toggle()
+  }
+}
+
+func isPauseSignal(sig os.Signal) bool {
+  for _, s := range pauseSignals {
+    if s == sig {
+      return true
+    }
+  }
+
+  return false
+}
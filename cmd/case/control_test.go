@@ -0,0 +1,60 @@
+package main
+
+import (
+  "time"
+  "context"
+  "testing"
+)
+
+func TestControlPause(t *testing.T) {
+  c := NewControl(context.Background(), false)
+  defer c.Stop()
+
+  if err := c.Wait(); err != nil {
+    t.Fatal(err)
+  }
+
+  c.Toggle()
+  done := make(chan error)
+  go func() { done <- c.Wait() }()
+
+  select {
+  case err := <-done:
+    t.Fatalf("Wait returned while paused: %v", err)
+  case <-time.After(50 * time.Millisecond):
+  }
+
+  c.Toggle()
+  select {
+  case err := <-done:
+    if err != nil {
+      t.Fatal(err)
+    }
+  case <-time.After(time.Second):
+    t.Fatal("Wait did not return after resume")
+  }
+}
+
+func TestControlInterrupt(t *testing.T) {
+  c := NewControl(context.Background(), false)
+  defer c.Stop()
+
+  // 暂停中被中断时也应返回
+  c.Toggle()
+  done := make(chan error)
+  go func() { done <- c.Wait() }()
+  c.Interrupt()
+
+  select {
+  case err := <-done:
+    if err != ErrInterrupted {
+      t.Fatalf("err = %v, want %v", err, ErrInterrupted)
+    }
+  case <-time.After(time.Second):
+    t.Fatal("Wait did not return after interrupt")
+  }
+
+  if c.Context().Err() == nil {
+    t.Error("context not canceled")
+  }
+}
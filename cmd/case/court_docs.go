@@ -0,0 +1,43 @@
+// Code generated by internal/confdoc/gen from court/config.go; DO NOT EDIT.
+
+package main
+
+import (
+  "github.com/NataRich/auto-case/internal/confdoc"
+)
+
+// court包配置结构体的字段说明（见confDocs）
+var courtDocs = confdoc.Docs{
+  "CaseConfig": {
+    "": "案件设置",
+    "Agreement": "调解方案",
+    "AutoCreate": "自动生成调解协议",
+    "CaseCatalog": "案件类型",
+    "CauseCode": "案由",
+    "DefaultApplicant": "默认申请人信息",
+    "DefaultMediatorId": "默认调解员ID",
+    "DefaultRespondent": "默认被申请人信息",
+    "Dispute": "纠纷概况",
+    "DisputeType": "纠纷类型",
+    "EndTime": "调解结束日期（已弃用，总会被随机日期覆写）",
+    "StartTime": "调解开始日期（已弃用，总会被随机日期覆写）",
+    "State": "案件状态",
+    "SuccessState": "成功状态",
+    "Type": "调解类型",
+    "Year": "案件年份",
+  },
+  "PersonConfig": {
+    "": "申请人或被申请人设置",
+    "Address": "居住地址",
+    "AreaCode": "居住地代码",
+    "Birthday": "出生日期",
+    "CredentialsType": "证件类型",
+    "IDCardNo": "证件号码",
+    "Name": "姓名",
+    "Nation": "民族",
+    "Sex": "性别",
+    "StaticPhone": "固定电话",
+    "Tel": "手机号码",
+    "Type": "当事人类型",
+  },
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+  "io"
+  "os"
+  "reflect"
+  "strconv"
+  "strings"
+  "io/ioutil"
+  "encoding/json"
+)
+
+const (
+  ENV_PREFIX = "CASE"
+)
+
+// 配置项对应的环境变量名，如 request.cookie -> CASE_REQUEST_COOKIE
+func EnvName(path ...string) string {
+  return strings.ToUpper(strings.Join(append([]string{ ENV_PREFIX }, path...), "_"))
+}
+
+// 用CASE_*环境变量覆盖配置项
+func ApplyEnv(conf *GlobalConfig) error {
+  return applyEnv(reflect.ValueOf(conf).Elem(), nil)
+}
+
+func applyEnv(v reflect.Value, path []string) error {
+  t := v.Type()
+  for i := 0; i < t.NumField(); i++ {
+    name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+    if name == "" || name == "-" {
+      continue
+    }
+
+    field := v.Field(i)
+    key := append(append([]string{}, path...), name)
+
+    // 嵌套配置：为空时仅在存在对应环境变量时创建
+    if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct {
+      target := field
+      if field.IsNil() {
+        target = reflect.New(field.Type().Elem())
+      }
+
+      before := reflect.Indirect(target).Interface()
+      if err := applyEnv(target.Elem(), key); err != nil {
+        return err
+      }
+
+      if field.IsNil() && !reflect.DeepEqual(before, target.Elem().Interface()) {
+        field.Set(target)
+      }
+      continue
+    }
+
+    env := EnvName(key...)
+    value, ok := os.LookupEnv(env)
+    if !ok {
+      continue
+    }
+
+    if err := setField(field, value); err != nil {
+      return NewError("err.env", env, err)
+    }
+  }
+
+  return nil
+}
+
+// 将字符串形式的值写入配置项，映射类型使用JSON
+func setField(field reflect.Value, value string) error {
+  switch field.Kind() {
+  case reflect.String:
+    field.SetString(value)
+  case reflect.Int:
+    n, err := strconv.Atoi(strings.TrimSpace(value))
+    if err != nil {
+      return err
+    }
+    field.SetInt(int64(n))
+  case reflect.Bool:
+    b, err := strconv.ParseBool(strings.TrimSpace(value))
+    if err != nil {
+      return err
+    }
+    field.SetBool(b)
+  default:
+    ptr := reflect.New(field.Type())
+    if err := json.Unmarshal([]byte(value), ptr.Interface()); err != nil {
+      return err
+    }
+    field.Set(ptr.Elem())
+  }
+
+  return nil
+}
+
+// 读取cookie文件（"-"表示标准输入），文件中的cookie优先于配置项
+func ResolveCookie(req *RequestConfig) error {
+  if req == nil || req.CookieFile == "" {
+    return nil
+  }
+
+  var (
+    bytes []byte
+    err   error
+  )
+
+  if req.CookieFile == "-" {
+    bytes, err = io.ReadAll(os.Stdin)
+  } else {
+    bytes, err = ioutil.ReadFile(req.CookieFile)
+  }
+
+  if err != nil {
+    return NewError("err.cookieRead", err)
+  }
+
+  req.Cookie = strings.TrimSpace(string(bytes))
+  return nil
+}
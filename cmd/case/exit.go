@@ -0,0 +1,70 @@
+package main
+
+import (
+  "errors"
+  "strconv"
+
+  "github.com/NataRich/auto-case/court"
+)
+
+// 进程退出码
+const (
+  // 全部成功
+  EXIT_OK           = 0
+
+  // 配置无效（含命令行参数错误）
+  EXIT_CONFIG       = 1
+
+  // 部分行检查未通过或新建失败
+  EXIT_PARTIAL      = 2
+
+  // 会话已失效，需更新cookie
+  EXIT_SESSION      = 3
+
+  // 无法读取数据源
+  EXIT_DATA         = 4
+
+  // 被中断（Ctrl-C）
+  EXIT_INTERRUPTED  = 130
+)
+
+// 部分行未能新建
+var ErrPartialFailure = NewError("err.partialFailure")
+
+// 无法读取数据源
+type DataError struct {
+  Path    string
+  Err     error
+}
+
+func (e *DataError) Error() string {
+  return T("err.dataUnreadable", e.Path, e.Err)
+}
+
+func (e *DataError) Unwrap() error {
+  return e.Err
+}
+
+// 由命令返回的错误确定退出码
+func ExitCode(err error) int {
+  var dataErr *DataError
+  switch {
+  case err == nil:
+    return EXIT_OK
+  case errors.Is(err, ErrInterrupted):
+    return EXIT_INTERRUPTED
+  case errors.Is(err, court.ErrSessionExpired):
+    return EXIT_SESSION
+  case errors.As(err, &dataErr):
+    return EXIT_DATA
+  case errors.Is(err, ErrPartialFailure), errors.Is(err, court.ErrUnknownResult):
+    return EXIT_PARTIAL
+  }
+
+  return EXIT_CONFIG
+}
+
+// 退出码的说明
+func ExitMessage(code int) string {
+  return T("exit." + strconv.Itoa(code))
+}
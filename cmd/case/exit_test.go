@@ -0,0 +1,31 @@
+package main
+
+import (
+  "fmt"
+  "errors"
+  "testing"
+
+  "github.com/NataRich/auto-case/court"
+)
+
+func TestExitCode(t *testing.T) {
+  tests := []struct {
+    err  error
+    want int
+  }{
+    { nil, EXIT_OK },
+    { NewError("err.precheck"), EXIT_CONFIG },
+    { ErrPartialFailure, EXIT_PARTIAL },
+    { court.ErrUnknownResult, EXIT_PARTIAL },
+    { court.ErrSessionExpired, EXIT_SESSION },
+    { fmt.Errorf("row 3: %w", court.ErrSessionExpired), EXIT_SESSION },
+    { &DataError{ Path: "data.xlsx", Err: errors.New("no such file") }, EXIT_DATA },
+    { ErrInterrupted, EXIT_INTERRUPTED },
+  }
+
+  for _, tt := range tests {
+    if got := ExitCode(tt.err); got != tt.want {
+      t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+    }
+  }
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+  "strconv"
+  "strings"
+  "unicode"
+
+  "github.com/xuri/excelize/v2"
+)
+
+// 行筛选表达式，按列号引用单元格，如 F > 5000 && G == "劳动争议"
+//
+// 支持 == != > >= < <= 比较，&& || ! 与括号；两侧均为数字时按数值比较，
+// 否则 == 与 != 按文本比较，其余比较不成立；单独的列号表示该单元格非空
+type Filter struct {
+  src         string
+  root        filterNode
+}
+
+type filterNode interface {
+  match(row []string) bool
+}
+
+// 解析筛选表达式
+func ParseFilter(src string) (*Filter, error) {
+  tokens, err := lexFilter(src)
+  if err != nil {
+    return nil, err
+  }
+
+  p := &filterParser{ src: src, tokens: tokens }
+  root, err := p.or()
+  if err != nil {
+    return nil, err
+  }
+
+  if !p.done() {
+    return nil, p.errorf(p.peek().text)
+  }
+
+  return &Filter{ src: src, root: root }, nil
+}
+
+// 该行是否满足条件
+func (f *Filter) Match(row []string) bool {
+  return f.root.match(row)
+}
+
+func (f *Filter) String() string {
+  return f.src
+}
+
+// 词法单元
+type filterToken struct {
+  kind        int
+  text        string
+}
+
+const (
+  TOKEN_COLUMN = iota
+  TOKEN_NUMBER
+  TOKEN_STRING
+  TOKEN_OP
+)
+
+var filterOps = []string{ "&&", "||", "==", "!=", ">=", "<=", ">", "<", "!", "(", ")" }
+
+func lexFilter(src string) ([]filterToken, error) {
+  var tokens []filterToken
+  r := []rune(src)
+  for i := 0; i < len(r); {
+    c := r[i]
+    switch {
+    case unicode.IsSpace(c):
+      i++
+
+    case c == '"' || c == '\'':
+      j := i + 1
+      for j < len(r) && r[j] != c {
+        j++
+      }
+      if j == len(r) {
+        return nil, NewError("err.filter", src, string(r[i:]))
+      }
+      tokens = append(tokens, filterToken{ TOKEN_STRING, string(r[i + 1:j]) })
+      i = j + 1
+
+    case unicode.IsDigit(c) || c == '.' || (c == '-' && i + 1 < len(r) && unicode.IsDigit(r[i + 1])):
+      j := i + 1
+      for j < len(r) && (unicode.IsDigit(r[j]) || r[j] == '.') {
+        j++
+      }
+      tokens = append(tokens, filterToken{ TOKEN_NUMBER, string(r[i:j]) })
+      i = j
+
+    case c < unicode.MaxASCII && unicode.IsLetter(c):
+      j := i + 1
+      for j < len(r) && r[j] < unicode.MaxASCII && unicode.IsLetter(r[j]) {
+        j++
+      }
+      tokens = append(tokens, filterToken{ TOKEN_COLUMN, strings.ToUpper(string(r[i:j])) })
+      i = j
+
+    default:
+      op := ""
+      for _, o := range filterOps {
+        if strings.HasPrefix(string(r[i:]), o) {
+          op = o
+          break
+        }
+      }
+      if op == "" {
+        return nil, NewError("err.filter", src, string(c))
+      }
+      tokens = append(tokens, filterToken{ TOKEN_OP, op })
+      i += len([]rune(op))
+    }
+  }
+
+  return tokens, nil
+}
+
+// 递归下降语法分析：or := and (|| and)*，and := not (&& not)*，
+// not := ! not | ( or ) | 比较，比较 := 操作数 [运算符 操作数]
+type filterParser struct {
+  src         string
+  tokens      []filterToken
+  pos         int
+}
+
+func (p *filterParser) done() bool {
+  return p.pos >= len(p.tokens)
+}
+
+func (p *filterParser) peek() filterToken {
+  if p.done() {
+    return filterToken{ TOKEN_OP, "" }
+  }
+  return p.tokens[p.pos]
+}
+
+func (p *filterParser) accept(op string) bool {
+  t := p.peek()
+  if t.kind == TOKEN_OP && t.text == op && !p.done() {
+    p.pos++
+    return true
+  }
+  return false
+}
+
+func (p *filterParser) errorf(near string) error {
+  if near == "" {
+    return NewError("err.filterEnd", p.src)
+  }
+  return NewError("err.filter", p.src, near)
+}
+
+func (p *filterParser) or() (filterNode, error) {
+  left, err := p.and()
+  if err != nil {
+    return nil, err
+  }
+
+  for p.accept("||") {
+    right, err := p.and()
+    if err != nil {
+      return nil, err
+    }
+    left = orNode{ left, right }
+  }
+
+  return left, nil
+}
+
+func (p *filterParser) and() (filterNode, error) {
+  left, err := p.not()
+  if err != nil {
+    return nil, err
+  }
+
+  for p.accept("&&") {
+    right, err := p.not()
+    if err != nil {
+      return nil, err
+    }
+    left = andNode{ left, right }
+  }
+
+  return left, nil
+}
+
+func (p *filterParser) not() (filterNode, error) {
+  if p.accept("!") {
+    n, err := p.not()
+    if err != nil {
+      return nil, err
+    }
+    return notNode{ n }, nil
+  }
+
+  if p.accept("(") {
+    n, err := p.or()
+    if err != nil {
+      return nil, err
+    }
+    if !p.accept(")") {
+      return nil, p.errorf(p.peek().text)
+    }
+    return n, nil
+  }
+
+  left, err := p.operand()
+  if err != nil {
+    return nil, err
+  }
+
+  t := p.peek()
+  switch t.text {
+  case "==", "!=", ">", ">=", "<", "<=":
+    if t.kind != TOKEN_OP {
+      break
+    }
+    p.pos++
+    right, err := p.operand()
+    if err != nil {
+      return nil, err
+    }
+    return compareNode{ t.text, left, right }, nil
+  }
+
+  // 单独的列号：单元格非空
+  if left.col == 0 {
+    return nil, p.errorf(t.text)
+  }
+  return compareNode{ "!=", left, operand{} }, nil
+}
+
+func (p *filterParser) operand() (operand, error) {
+  t := p.peek()
+  if p.done() {
+    return operand{}, p.errorf("")
+  }
+
+  switch t.kind {
+  case TOKEN_COLUMN:
+    col, err := excelize.ColumnNameToNumber(t.text)
+    if err != nil {
+      return operand{}, NewError("err.filterColumn", p.src, t.text)
+    }
+    p.pos++
+    return operand{ col: col }, nil
+
+  case TOKEN_NUMBER, TOKEN_STRING:
+    p.pos++
+    return operand{ lit: t.text }, nil
+  }
+
+  return operand{}, p.errorf(t.text)
+}
+
+// 操作数：列号（从1开始）或字面量
+type operand struct {
+  col         int
+  lit         string
+}
+
+func (o operand) value(row []string) string {
+  if o.col == 0 {
+    return o.lit
+  }
+  return cellAt(row, o.col)
+}
+
+// 解析数值，忽略千分位逗号
+func filterNumber(s string) (float64, bool) {
+  s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+  if s == "" {
+    return 0, false
+  }
+
+  f, err := strconv.ParseFloat(s, 64)
+  return f, err == nil
+}
+
+type compareNode struct {
+  op          string
+  left        operand
+  right       operand
+}
+
+func (n compareNode) match(row []string) bool {
+  l, r := n.left.value(row), n.right.value(row)
+  x, xok := filterNumber(l)
+  y, yok := filterNumber(r)
+
+  if xok && yok {
+    switch n.op {
+    case "==": return x == y
+    case "!=": return x != y
+    case ">":  return x > y
+    case ">=": return x >= y
+    case "<":  return x < y
+    case "<=": return x <= y
+    }
+  }
+
+  switch n.op {
+  case "==": return l == r
+  case "!=": return l != r
+  }
+
+  return false
+}
+
+type andNode struct {
+  left        filterNode
+  right       filterNode
+}
+
+func (n andNode) match(row []string) bool {
+  return n.left.match(row) && n.right.match(row)
+}
+
+type orNode struct {
+  left        filterNode
+  right       filterNode
+}
+
+func (n orNode) match(row []string) bool {
+  return n.left.match(row) || n.right.match(row)
+}
+
+type notNode struct {
+  n           filterNode
+}
+
+func (n notNode) match(row []string) bool {
+  return !n.n.match(row)
+}
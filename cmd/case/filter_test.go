@@ -0,0 +1,58 @@
+package main
+
+import (
+  "testing"
+)
+
+func TestFilter(t *testing.T) {
+  row := []string{ "张三", "李四", "", "", "", "5,200", "劳动争议" }
+
+  tests := []struct {
+    expr string
+    want bool
+  }{
+    { `F > 5000`, true },
+    { `F > 5000 && G == "劳动争议"`, true },
+    { `F > 5000 && G == '合同纠纷'`, false },
+    { `F < 5000 || G != "合同纠纷"`, true },
+    { `!(F >= 5200)`, false },
+    { `F == 5200.0`, true },
+    { `a == "张三"`, true },
+    { `C`, false },
+    { `!C && B`, true },
+    { `C > 0`, false },
+    { `Z == ""`, true },
+    { `F > -1`, true },
+  }
+
+  for _, tt := range tests {
+    f, err := ParseFilter(tt.expr)
+    if err != nil {
+      t.Errorf("ParseFilter(%q): %v", tt.expr, err)
+      continue
+    }
+
+    if got := f.Match(row); got != tt.want {
+      t.Errorf("%q = %v, want %v", tt.expr, got, tt.want)
+    }
+  }
+}
+
+func TestFilterInvalid(t *testing.T) {
+  for _, expr := range []string{
+    ``,
+    `F >`,
+    `F > 5000 &&`,
+    `(F > 5000`,
+    `F > 5000)`,
+    `G == "劳动争议`,
+    `5000`,
+    `F = 5000`,
+    `F > 5000 G`,
+    `XFDA == 1`,
+  } {
+    if _, err := ParseFilter(expr); err == nil {
+      t.Errorf("ParseFilter(%q) succeeded", expr)
+    }
+  }
+}
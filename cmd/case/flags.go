@@ -0,0 +1,166 @@
+package main
+
+import (
+  "github.com/urfave/cli/v2"
+)
+
+// 全局参数
+var globalFlags = []cli.Flag{
+  &cli.StringFlag{
+    Name: "config",
+    Aliases: []string{ "c" },
+    Value: CONFIG_FILE,
+    Usage: T("flag.config"),
+  },
+  &cli.BoolFlag{
+    Name: "unmask",
+    Usage: T("flag.unmask"),
+  },
+  // 语言在解析命令行前已由DetectLang确定，此处只为接受该参数
+  &cli.StringFlag{
+    Name: "lang",
+    Usage: T("flag.lang"),
+  },
+}
+
+// 数据源参数，覆盖配置文件中的data配置
+var dataFlags = []cli.Flag{
+  &cli.StringFlag{
+    Name: "sheet",
+    Usage: T("flag.sheet"),
+  },
+  &cli.IntFlag{
+    Name: "skip",
+    Usage: T("flag.skip"),
+  },
+  &cli.IntFlag{
+    Name: "count",
+    Usage: T("flag.count"),
+  },
+  &cli.StringFlag{
+    Name: "rows",
+    Usage: T("flag.rows"),
+  },
+  &cli.StringFlag{
+    Name: "where",
+    Usage: T("flag.where"),
+  },
+}
+
+// 新建案例参数，覆盖配置文件中的request与debug配置
+var requestFlags = []cli.Flag{
+  &cli.BoolFlag{
+    Name: "fake",
+    Usage: T("flag.fake"),
+  },
+  &cli.BoolFlag{
+    Name: "verbose",
+    Usage: T("flag.verbose"),
+  },
+  &cli.IntFlag{
+    Name: "delay",
+    Usage: T("flag.delay"),
+  },
+  &cli.StringFlag{
+    Name: "cookie-file",
+    Usage: T("flag.cookieFile"),
+  },
+}
+
+// 加载配置文件，依次用配置方案、环境变量、命令行参数覆盖，最后读取cookie文件
+func loadConf(ctx *cli.Context) error {
+  if _, err := LoadConf(ctx.String("config")); err != nil {
+    return err
+  }
+
+  cookieSaved := Conf.Request != nil && Conf.Request.Cookie != ""
+  tokenSaved := Conf.Serve != nil && Conf.Serve.Token != ""
+
+  if name := ctx.String("profile"); name != "" {
+    conf, err := ApplyProfile(Conf, name)
+    if err != nil {
+      return err
+    }
+    Conf = conf
+  }
+
+  if err := ApplyEnv(Conf); err != nil {
+    return err
+  }
+
+  if err := applyFlags(ctx, Conf); err != nil {
+    return err
+  }
+
+  if err := SetupLogger(Conf.Debug); err != nil {
+    return err
+  }
+
+  Masking = Conf.Mask
+  if ctx.Bool("unmask") {
+    Masking = NoMask()
+    Logger.Warn(T("log.unmasked"))
+  }
+
+  if cookieSaved {
+    Logger.Debug(T("log.cookieInConf"),
+                 "env", EnvName("request", "cookie"))
+  }
+
+  if tokenSaved {
+    Logger.Debug(T("log.tokenInConf"), "env", EnvName("serve", "token"))
+  }
+
+  if name := ctx.String("profile"); name != "" {
+    Logger.Debug(T("log.profile"), "profile", name)
+  }
+
+  return ResolveCookie(Conf.Request)
+}
+
+// 用命令行参数覆盖已加载的配置，未指定的参数保持配置文件中的值
+func applyFlags(ctx *cli.Context, conf *GlobalConfig) error {
+  if conf.Data != nil {
+    if ctx.IsSet("sheet") {
+      conf.Data.Sheet = ctx.String("sheet")
+    }
+
+    if ctx.IsSet("skip") {
+      conf.Data.SkipLines = ctx.Int("skip")
+    }
+
+    if ctx.IsSet("count") {
+      conf.Data.ExecCount = ctx.Int("count")
+    }
+
+    if ctx.IsSet("rows") {
+      conf.Data.Rows = ctx.String("rows")
+    }
+
+    if ctx.IsSet("where") {
+      conf.Data.Where = ctx.String("where")
+    }
+  }
+
+  if conf.Debug != nil {
+    if ctx.IsSet("fake") {
+      conf.Debug.Fake = ctx.Bool("fake")
+    }
+
+    if ctx.IsSet("verbose") {
+      conf.Debug.Verbose = ctx.Bool("verbose")
+    }
+  }
+
+  if conf.Request != nil {
+    if ctx.IsSet("delay") {
+      conf.Request.Delay = ctx.Int("delay")
+    }
+
+    if ctx.IsSet("cookie-file") {
+      conf.Request.CookieFile = ctx.String("cookie-file")
+    }
+  }
+
+  return nil
+}
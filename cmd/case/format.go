@@ -0,0 +1,252 @@
+package main
+
+import (
+  "fmt"
+  "bytes"
+  "reflect"
+  "strings"
+  "path/filepath"
+  "encoding/json"
+
+  "gopkg.in/yaml.v3"
+  "github.com/BurntSushi/toml"
+)
+
+// 配置文件格式
+const (
+  FORMAT_JSON = "json"
+  FORMAT_YAML = "yaml"
+  FORMAT_TOML = "toml"
+)
+
+// 按扩展名判断配置文件格式，默认为JSON
+func ConfFormat(path string) string {
+  switch strings.ToLower(filepath.Ext(path)) {
+  case ".yaml", ".yml":
+    return FORMAT_YAML
+  case ".toml":
+    return FORMAT_TOML
+  }
+
+  return FORMAT_JSON
+}
+
+// 将YAML/TOML配置转换为JSON，以便统一按json标签解析；
+// 字符串配置项中未加引号的数字、布尔值（如 type: 0、nation: 01）按原文作为字符串
+func DecodeConf(path string, data []byte) ([]byte, error) {
+  var doc interface{}
+  conf := reflect.TypeOf(GlobalConfig{})
+  switch ConfFormat(path) {
+  case FORMAT_YAML:
+    var node yaml.Node
+    if err := yaml.Unmarshal(data, &node); err != nil {
+      return nil, NewError("err.yaml", err)
+    }
+
+    if len(node.Content) > 0 {
+      v, err := yamlValue(node.Content[0], conf)
+      if err != nil {
+        return nil, NewError("err.yaml", err)
+      }
+      doc = v
+    }
+  case FORMAT_TOML:
+    var m map[string]interface{}
+    if _, err := toml.Decode(string(data), &m); err != nil {
+      return nil, NewError("err.toml", err)
+    }
+    doc = tomlValue(m, conf)
+  default:
+    return data, nil
+  }
+
+  if doc == nil {
+    doc = map[string]interface{}{}
+  }
+
+  return json.Marshal(doc)
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// 配置项的类型，t为上一级的类型，未知配置项为nil
+func fieldType(t reflect.Type, key string) reflect.Type {
+  if t == nil {
+    return nil
+  }
+
+  for t.Kind() == reflect.Ptr {
+    t = t.Elem()
+  }
+
+  switch t.Kind() {
+  case reflect.Map:
+    // 配置方案为部分覆盖的全局配置
+    if t.Elem() == rawMessageType {
+      return reflect.TypeOf(GlobalConfig{})
+    }
+    return t.Elem()
+  case reflect.Struct:
+    for i := 0; i < t.NumField(); i++ {
+      f := t.Field(i)
+      if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name == key {
+        return f.Type
+      }
+    }
+  }
+
+  return nil
+}
+
+// 数组元素的类型
+func elemType(t reflect.Type) reflect.Type {
+  if t == nil || t.Kind() != reflect.Slice {
+    return nil
+  }
+
+  // 多个数据源中的每一项为部分覆盖的数据源配置
+  if t.Elem() == rawMessageType {
+    return reflect.TypeOf(DataConfig{})
+  }
+  return t.Elem()
+}
+
+func isString(t reflect.Type) bool {
+  return t != nil && t.Kind() == reflect.String
+}
+
+// 按配置项类型转换YAML节点
+func yamlValue(node *yaml.Node, t reflect.Type) (interface{}, error) {
+  switch node.Kind {
+  case yaml.AliasNode:
+    return yamlValue(node.Alias, t)
+  case yaml.MappingNode:
+    m := map[string]interface{}{}
+    for i := 0; i + 1 < len(node.Content); i += 2 {
+      k, v := node.Content[i], node.Content[i + 1]
+      value, err := yamlValue(v, fieldType(t, k.Value))
+      if err != nil {
+        return nil, err
+      }
+      m[k.Value] = value
+    }
+    return m, nil
+  case yaml.SequenceNode:
+    list := []interface{}{}
+    for _, item := range node.Content {
+      value, err := yamlValue(item, elemType(t))
+      if err != nil {
+        return nil, err
+      }
+      list = append(list, value)
+    }
+    return list, nil
+  case yaml.ScalarNode:
+    if isString(t) && node.Tag != "!!null" {
+      return node.Value, nil
+    }
+  }
+
+  var v interface{}
+  if err := node.Decode(&v); err != nil {
+    return nil, err
+  }
+  return v, nil
+}
+
+// 按配置项类型转换TOML值
+func tomlValue(v interface{}, t reflect.Type) interface{} {
+  switch x := v.(type) {
+  case map[string]interface{}:
+    for k, item := range x {
+      x[k] = tomlValue(item, fieldType(t, k))
+    }
+  case []map[string]interface{}:
+    list := []interface{}{}
+    for _, item := range x {
+      list = append(list, tomlValue(item, elemType(t)))
+    }
+    return list
+  case []interface{}:
+    for i, item := range x {
+      x[i] = tomlValue(item, elemType(t))
+    }
+  case int64, float64, bool:
+    if isString(t) {
+      return fmt.Sprint(x)
+    }
+  }
+
+  return v
+}
+
+// 将JSON配置转换为目标格式
+func EncodeConf(path string, data []byte) ([]byte, error) {
+  switch ConfFormat(path) {
+  case FORMAT_YAML:
+    // YAML是JSON的超集，借助节点保留字段顺序
+    var node yaml.Node
+    if err := yaml.Unmarshal(data, &node); err != nil {
+      return nil, err
+    }
+    blockStyle(&node)
+
+    var buf bytes.Buffer
+    enc := yaml.NewEncoder(&buf)
+    enc.SetIndent(2)
+    if err := enc.Encode(&node); err != nil {
+      return nil, err
+    }
+    return buf.Bytes(), nil
+  case FORMAT_TOML:
+    // 保留数字原文，以免整数写成浮点数（如 version = 1.0）
+    var doc map[string]interface{}
+    dec := json.NewDecoder(bytes.NewReader(data))
+    dec.UseNumber()
+    if err := dec.Decode(&doc); err != nil {
+      return nil, err
+    }
+
+    var buf bytes.Buffer
+    if err := toml.NewEncoder(&buf).Encode(tomlNumbers(doc)); err != nil {
+      return nil, err
+    }
+    return buf.Bytes(), nil
+  }
+
+  return data, nil
+}
+
+// 数字转换为TOML的整数或浮点数
+func tomlNumbers(v interface{}) interface{} {
+  switch x := v.(type) {
+  case map[string]interface{}:
+    for k, item := range x {
+      x[k] = tomlNumbers(item)
+    }
+  case []interface{}:
+    for i, item := range x {
+      x[i] = tomlNumbers(item)
+    }
+  case json.Number:
+    if n, err := x.Int64(); err == nil {
+      return n
+    }
+    f, _ := x.Float64()
+    return f
+  }
+
+  return v
+}
+
+// 使用块格式，多行文本使用字面量格式，便于编辑纠纷概况、调解方案等长文本
+func blockStyle(node *yaml.Node) {
+  node.Style = 0
+  if node.Kind == yaml.ScalarNode && node.Tag == "!!str" && strings.Contains(node.Value, "\n") {
+    node.Style = yaml.LiteralStyle
+  }
+
+  for _, child := range node.Content {
+    blockStyle(child)
+  }
+}
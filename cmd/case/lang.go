@@ -0,0 +1,447 @@
+package main
+
+import (
+  "os"
+  "fmt"
+  "errors"
+  "strings"
+
+  "github.com/NataRich/auto-case/court"
+)
+
+// 界面语言
+type Lang string
+
+const (
+  LANG_ZH Lang = "zh-CN"
+  LANG_EN Lang = "en"
+)
+
+// 当前界面语言，由--lang参数或LANG等环境变量决定
+var CurrentLang = DetectLang(os.Args[1:], os.Getenv)
+
+// 解析语言名称，如 en_US.UTF-8、zh-CN
+func ParseLang(s string) (Lang, bool) {
+  s = strings.ToLower(strings.TrimSpace(s))
+  switch {
+  case strings.HasPrefix(s, "zh"):
+    return LANG_ZH, true
+  case strings.HasPrefix(s, "en"):
+    return LANG_EN, true
+  }
+
+  return "", false
+}
+
+// 依次按命令行中的--lang参数、LC_ALL、LC_MESSAGES、LANG选择语言，默认中文。
+// 帮助信息在命令行解析前生成，因此需预先扫描参数
+func DetectLang(args []string, getenv func(string) string) Lang {
+  for i, arg := range args {
+    if arg == "--" {
+      break
+    }
+
+    var value string
+    switch {
+    case arg == "--lang" || arg == "-lang":
+      if i + 1 < len(args) {
+        value = args[i + 1]
+      }
+    case strings.HasPrefix(arg, "--lang="):
+      value = strings.TrimPrefix(arg, "--lang=")
+    case strings.HasPrefix(arg, "-lang="):
+      value = strings.TrimPrefix(arg, "-lang=")
+    default:
+      continue
+    }
+
+    if lang, ok := ParseLang(value); ok {
+      return lang
+    }
+  }
+
+  for _, env := range []string{ "LC_ALL", "LC_MESSAGES", "LANG" } {
+    if lang, ok := ParseLang(getenv(env)); ok {
+      return lang
+    }
+  }
+
+  return LANG_ZH
+}
+
+// 翻译消息，key为消息编号（检查问题的编号保持不变，不随语言变化）
+func T(key string, args ...interface{}) string {
+  msg, ok := messages[key]
+  if !ok {
+    return key
+  }
+
+  s := msg.Zh
+  if CurrentLang == LANG_EN && msg.En != "" {
+    s = msg.En
+  }
+
+  if len(args) > 0 {
+    return fmt.Sprintf(s, args...)
+  }
+
+  return s
+}
+
+// court包的消息同样使用本目录，其中的电话号码按遮盖策略遮盖
+var CourtLocale = &court.Locale{
+  Translate:  T,
+  MaskPhone:  func(phone string) string {
+    return Masking.Phone(phone)
+  },
+}
+
+// 以翻译后的消息生成错误
+func NewError(key string, args ...interface{}) error {
+  return errors.New(T(key, args...))
+}
+
+// 消息的各语言文本
+type Message struct {
+  Zh          string
+  En          string
+}
+
+// 消息目录，大写编号为检查问题的编号（见 case validate --format json 的code字段）
+var messages = map[string]Message{
+  // 检查问题：全局
+  "CONF_EMPTY":                   { "全局配置为空", "configuration is empty" },
+  "CONF_KEY_UNKNOWN":             { "未知的配置项，将被忽略（请检查拼写）", "unknown key, it will be ignored (check the spelling)" },
+  "CONF_VERSION_OLD":             { "配置文件为旧版本（%d），已按当前版本读取但未改写；可运行 case --config %s init --from %s 升级（原文件会备份）", "the config file is an old version (%d), it was read as the current version but not rewritten; run case --config %s init --from %s to upgrade it (the original is backed up)" },
+  "CONF_KEY_DEPRECATED":          { "配置项已弃用：%s", "deprecated key: %s" },
+  "PROFILE_COL_WITHOUT_PROFILES": { "已配置方案列号，但配置文件中没有配置方案", "data.profileCol is set but the config has no profiles" },
+  "PROFILE_INVALID":              { "%v", "%v" },
+  "PROFILE_UNKNOWN":              { "未知的配置方案：%s", "unknown profile: %s" },
+
+  // 检查问题：数据源
+  "DATA_EMPTY":                   { "数据源配置不得为空", "data source config must not be empty" },
+  "DATA_PATH_EMPTY":              { "excel数据表路径不得为空", "excel workbook path must not be empty" },
+  "DATA_SHEET_EMPTY":             { "excel工作表名不得为空", "excel sheet name must not be empty" },
+  "DATA_SKIP_NEGATIVE":           { "跳过行数不得为负数", "number of rows to skip must not be negative" },
+  "DATA_COUNT_INVALID":           { "执行行数不得为0或负数", "number of rows to run must be positive" },
+  "DATA_APPLICANT_COL_EMPTY":     { "申请人列号不得为空", "applicant column must not be empty" },
+  "DATA_APPLICANT_COL_INVALID":   { "申请人列号错误：%s", "invalid applicant column: %s" },
+  "DATA_RESPONDENT_COL_EMPTY":    { "被申请人列号不得为空", "respondent column must not be empty" },
+  "DATA_RESPONDENT_COL_INVALID":  { "被申请人列号错误：%s", "invalid respondent column: %s" },
+  "DATA_PROFILE_COL_INVALID":     { "配置方案列号错误：%s", "invalid profile column: %s" },
+  "DATA_MAPPER_INVALID":          { "%v", "%v" },
+  "DATA_MAPPER_COL_INVALID":      { "自定义配置%s的列号错误：%s", "invalid column for mapping %s: %s" },
+  "DATA_ROWS_INVALID":            { "指定行号无效：%v", "invalid row selection: %v" },
+  "DATA_WHERE_INVALID":           { "筛选表达式无效：%v", "invalid filter expression: %v" },
+  "DATA_SOURCE_INVALID":          { "数据源列表格式错误：%v", "invalid data sources: %v" },
+  "DATA_UNREADABLE":              { "无法读取数据源：%v", "cannot read the data source: %v" },
+  "ROW_UNREADABLE":               { "无法读取该行：%v", "cannot read this row: %v" },
+
+  // 检查问题：监视目录
+  "WATCH_INTERVAL_INVALID":       { "扫描间隔须为正数", "watch interval must be positive" },
+  "WATCH_SETTLE_NEGATIVE":        { "文件稳定时间不得为负数", "settle time must not be negative" },
+  "WATCH_PATTERN_INVALID":        { "文件名通配符无效：%v", "invalid file name pattern: %v" },
+
+  // 检查问题：提交时间安排
+  "SCHEDULE_WINDOW_INVALID":      { "%v", "%v" },
+  "SCHEDULE_WEEKDAY_INVALID":     { "星期只能为1（周一）至7（周日）：%d", "weekday must be 1 (Monday) to 7 (Sunday): %d" },
+  "SCHEDULE_LIMIT_NEGATIVE":      { "每日上限不得为负数", "daily limit must not be negative" },
+
+  // 检查问题：接口服务
+  "SERVE_TOKEN_EMPTY":            { "访问令牌不得为空（可用环境变量%s设置）", "access token must not be empty (it can be set with %s)" },
+  "SERVE_QUEUE_NEGATIVE":         { "排队上限不得为负数", "queue size must not be negative" },
+
+  // 检查问题：请求与调试
+  "REQUEST_EMPTY":                { "请求配置不得为空", "request config must not be empty" },
+  "REQUEST_DELAY_NEGATIVE":       { "单次请求延迟不得为负数", "request delay must not be negative" },
+  "REQUEST_RETRY_NEGATIVE":       { "单次请求重试次数不得为负数", "retry count must not be negative" },
+  "REQUEST_TIMEOUT_NEGATIVE":     { "单次请求超时时长不得为负数", "request timeout must not be negative" },
+  "REQUEST_COOKIE_EMPTY":         { "请求Cookie配置不得为空", "request cookie must not be empty" },
+  "DEBUG_EMPTY":                  { "调试配置为空", "debug config is empty" },
+  "DEBUG_LOG_PATH_EMPTY":         { "错误日志路径为空（不必要，但强烈建议配置！）", "error log path is empty (optional, but strongly recommended)" },
+  "DEBUG_LOG_FORMAT_INVALID":     { "日志格式只能为text或json", "log format must be text or json" },
+  "DEBUG_LOG_LEVEL_INVALID":      { "%v", "%v" },
+
+  // 检查问题：案件
+  "CASE_EMPTY":                   { "案件配置不得为空", "case config must not be empty" },
+  "CASE_TYPE_EMPTY":              { "调解类型不得为空", "mediation type must not be empty" },
+  "CASE_YEAR_EMPTY":              { "案件年份不得为空", "case year must not be empty" },
+  "CASE_CATALOG_EMPTY":           { "案件类型不得为空", "case catalog must not be empty" },
+  "CASE_DISPUTE_TYPE_EMPTY":      { "纠纷类型不得为空", "dispute type must not be empty" },
+  "CASE_CAUSE_EMPTY":             { "案由不得为空", "cause code must not be empty" },
+  "CASE_STATE_EMPTY":             { "案件状态不得为空", "case state must not be empty" },
+  "CASE_SUCCESS_STATE_EMPTY":     { "成功状态为空（个别情况下允许）", "success state is empty (allowed in some cases)" },
+  "CASE_DISPUTE_EMPTY":           { "纠纷概况不得为空", "dispute summary must not be empty" },
+  "CASE_AGREEMENT_EMPTY":         { "调解方案不得为空", "mediation agreement must not be empty" },
+  "CASE_AUTO_CREATE_EMPTY":       { "是否自动生成调解协议为空（个别情况下允许）", "auto-create agreement flag is empty (allowed in some cases)" },
+  "CASE_MEDIATOR_EMPTY":          { "默认调解员ID不得为空", "default mediator ID must not be empty" },
+  "CASE_APPLICANT_EMPTY":         { "默认申请人信息为空", "default applicant is empty" },
+  "CASE_RESPONDENT_EMPTY":        { "默认被申请人信息为空", "default respondent is empty" },
+
+  // 检查问题：当事人
+  "PARTY_EMPTY":                  { "%s信息为空", "%s is empty" },
+  "PARTY_TYPE_EMPTY":             { "当事人类型为空", "party type is empty" },
+  "PARTY_NAME_EMPTY":             { "当事人姓名为空", "party name is empty" },
+  "PARTY_TEL_EMPTY":              { "当事人手机号为空", "party mobile number is empty" },
+  "PARTY_TEL_INVALID":            { "%s手机号无法识别（%s）：%v", "%s mobile number not recognized (%s): %v" },
+  "PARTY_STATIC_PHONE_INVALID":   { "%s固定电话无法识别（%s）：%v", "%s landline not recognized (%s): %v" },
+  "PARTY_CREDENTIALS_TYPE_EMPTY": { "当事人证件类型为空", "party credentials type is empty" },
+  "PARTY_ID_CARD_EMPTY":          { "当事人身份证号为空（个别情况下允许）", "party ID number is empty (allowed in some cases)" },
+  "PARTY_SEX_EMPTY":              { "当事人性别为空", "party sex is empty" },
+  "PARTY_BIRTHDAY_EMPTY":         { "当事人生日为空", "party birthday is empty" },
+  "PARTY_NATION_EMPTY":           { "当事人民族为空", "party ethnicity is empty" },
+  "PARTY_AREA_CODE_EMPTY":        { "当事人地区代号为空", "party area code is empty" },
+  "PARTY_ADDRESS_EMPTY":          { "当事人地址为空", "party address is empty" },
+
+  // 当事人与数据来源
+  "party.applicant":              { "申请人", "applicant" },
+  "party.respondent":             { "被申请人", "respondent" },
+  "party.either":                 { "申请人/被申请人", "applicant/respondent" },
+  "source.cell":                  { "单元格%s", "cell %s" },
+  "source.config":                { "配置项%s", "config key %s" },
+  "deprecated.randomDate":        { "调解日期总会被随机日期覆写，无需配置", "mediation dates are always overwritten with random dates" },
+
+  // 问题输出
+  "issue.error":                  { "错误", "error" },
+  "issue.warning":                { "警告", "warning" },
+  "issue.row":                    { "第%d行", "row %d" },
+  "issue.cell":                   { "（%s）", " (%s)" },
+  "issue.sep":                    { "：", ": " },
+  "list.sep":                     { "；", "; " },
+  "issue.header":                 { "级别\t编号\t行号\t字段\t单元格\t说明", "SEVERITY\tCODE\tROW\tFIELD\tCELL\tMESSAGE" },
+  "issue.total":                  { "共%d个错误，%d个警告", "%d error(s), %d warning(s)" },
+
+  // 错误
+  "err.format":                   { "未知的输出格式：%s", "unknown output format: %s" },
+  "err.precheck":                 { "预先检查失败，请检查配置文件", "pre-check failed, please check the config file" },
+  "err.validate":                 { "检查未通过，请根据以上问题修改配置文件/数据源", "validation failed, fix the config file or data source as listed above" },
+  "err.confExists":               { "配置文件%s已存在，如需覆盖请使用--force，如需补全新配置项请使用--from %s", "config file %s already exists, use --force to overwrite or --from %s to fill in new keys" },
+  "err.backup":                   { "无法备份配置文件：%v", "cannot back up the config file: %v" },
+  "err.confEmpty":                { "配置文件为空：%s", "config file is empty: %s" },
+  "err.notLoaded":                { "没有加载配置，无法保存", "no config loaded, nothing to save" },
+  "err.mapperKey":                { "自定义配置键格式错误：%s", "invalid mapping key: %s" },
+  "err.mapperParty":              { "自定义配置键的当事人未知：%s", "unknown party in mapping key: %s" },
+  "err.mapperField":              { "自定义配置键的字段未知：%s", "unknown field in mapping key: %s" },
+  "err.defaultParty":             { "默认%s为空", "default %s is empty" },
+  "err.fillRow":                  { "案件配置或数据源配置为空", "case or data source config is empty" },
+  "err.column":                   { "无法获取%s列名对应的索引：%v", "cannot resolve column %s: %v" },
+  "err.columnName":               { "列名应为A至XFD", "column names run from A to XFD" },
+  "err.cookieWrite":              { "无法写入cookie文件：%v", "cannot write the cookie file: %v" },
+  "err.cookieRead":               { "无法读取cookie文件：%v", "cannot read the cookie file: %v" },
+  "err.version":                  { "配置文件版本（%d）高于程序支持的版本（%d），请升级程序", "config version %d is newer than supported (%d), please upgrade" },
+  "err.migrate":                  { "配置文件从版本%d升级失败：%v", "failed to migrate config from version %d: %v" },
+  "err.env":                      { "环境变量%s的值无效：%v", "invalid value in environment variable %s: %v" },
+  "err.confType":                 { "配置文件%s中%s的类型应为%v", "config %s: %s must be of type %v" },
+  "err.yaml":                     { "YAML格式错误：%v", "invalid YAML: %v" },
+  "err.toml":                     { "TOML格式错误：%v", "invalid TOML: %v" },
+  "err.rowRange":                 { "行号范围格式错误：%s", "invalid row range: %s" },
+  "err.rowRangeEmpty":            { "行号范围无效：%s", "empty row range: %s" },
+  "err.sourceFormat":             { "第%d个数据源格式错误：%v", "data source #%d is invalid: %v" },
+  "err.sourceNoMatch":            { "没有匹配的工作簿", "no workbook matches" },
+  "err.filter":                   { "筛选表达式“%s”在“%s”处有误", "filter expression %q is invalid near %q" },
+  "err.filterEnd":                { "筛选表达式“%s”不完整", "filter expression %q is incomplete" },
+  "err.filterColumn":             { "筛选表达式“%s”中的列号%s无效", "filter expression %q has an invalid column %s" },
+  "err.profileUnknown":           { "未知的配置方案：%s（可用：%s）", "unknown profile: %s (available: %s)" },
+  "err.profileFormat":            { "配置方案%s格式错误：%v", "invalid profile %s: %v" },
+  "err.logLevel":                 { "日志级别只能为debug、info、warn或error：%s", "log level must be debug, info, warn or error: %s" },
+  "err.mobile":                   { "手机号格式错误（应为11位，以1开头）：%q", "invalid mobile number (11 digits starting with 1): %q" },
+  "err.landline":                 { "固定电话格式错误（应包含区号，如010-12345678）：%q", "invalid landline (include the area code, e.g. 010-12345678): %q" },
+  "err.blankRow":                 { "空行", "blank row" },
+  "err.requestArgs":              { "新建请求参数错误", "invalid request arguments" },
+  "err.sessionExpired":           { "会话已失效，请更新Cookie信息", "session expired, please update the cookie" },
+  "err.unknownResult":            { "新建结果未知，请手动确认", "result unknown, please check manually" },
+  "err.status":                   { "新建失败，返回值：%d", "creation failed with status %d" },
+  "err.rejected":                 { "新建失败，返回码为-1：%s", "creation failed with code -1: %s" },
+  "err.partialFailure":           { "部分行检查未通过或新建失败，详见运行报告", "some rows failed validation or submission, see the run report" },
+  "err.dataUnreadable":           { "无法读取数据源%s：%v", "cannot read the data source %s: %v" },
+  "err.interrupted":              { "已中断，剩余各行未处理", "interrupted, remaining rows were not processed" },
+  "err.watchDir":                 { "请指定要监视的目录", "specify an existing directory to watch" },
+  "err.fileClaimed":              { "该文件已开始提交，不再处理", "the file has already been submitted, not processing it again" },
+  "err.ledger":                   { "无法读写处理记录%s：%v", "cannot read or write the ledger %s: %v" },
+  "err.window":                   { "时间段格式错误（应如09:00-11:30）：%s", "invalid time window (e.g. 09:00-11:30): %s" },
+  "err.scheduleNever":            { "按提交时间安排，一周内都不允许提交", "the schedule allows no submission within a week" },
+  "err.method":                   { "不支持的请求方法：%s", "method not allowed: %s" },
+  "err.unauthorized":             { "访问令牌无效", "invalid access token" },
+  "err.requestBody":              { "请求体格式错误：%v", "invalid request body: %v" },
+  "err.queueFull":                { "排队的案件过多，请稍后再试", "too many queued cases, try again later" },
+  "err.jobUnknown":               { "未知或已清除的案件编号：%s", "unknown or expired case id: %s" },
+  "err.input":                    { "输入中断：%v", "input interrupted: %v" },
+  "err.inputBool":                { "请输入 是/否", "enter yes or no" },
+  "err.inputCount":               { "请输入非负整数", "enter a non-negative integer" },
+  "err.inputChoice":              { "不在可选范围内：%s", "not one of the choices: %s" },
+  "err.inputDate":                { "日期格式应为 2006-01-02", "dates must look like 2006-01-02" },
+  "err.inputYear":                { "年份格式错误", "invalid year" },
+  "err.inputCol":                 { "列号格式错误（如 A、AB）", "invalid column (e.g. A, AB)" },
+  "err.wizardBook":               { "无法打开excel数据表，之后将无法提示工作表与列：%v", "cannot open the workbook, sheets and columns will not be suggested: %v" },
+  "err.wizardSheet":              { "无法读取工作表：%v", "cannot read the sheet: %v" },
+
+  // 日志
+  "log.backup":                   { "原配置文件已备份", "previous config file backed up" },
+  "log.saved":                    { "配置已保存到%s，可使用 case validate 检查", "config saved to %s, run case validate to check it" },
+  "log.rowLoaded":                { "已载入该行当事人", "loaded parties for this row" },
+  "log.rowInvalid":               { "该行检查失败，将跳过该行", "row failed validation, skipping" },
+  "log.sending":                  { "开始发送请求", "sending request" },
+  "log.rowFailed":                { "重试后新建请求仍旧失败，将跳过该行", "request still failing after retries, skipping row" },
+  "log.reportFailed":             { "无法写入运行报告", "cannot write the run report" },
+  "log.reportSaved":              { "运行报告已写入", "run report written" },
+  "log.cookieNotSaved":           { "cookie不会写入新配置文件，请改用cookieFile或环境变量", "the cookie is not written to the new config, use cookieFile or an environment variable" },
+  "log.cookieMoved":              { "cookie已从配置文件移出", "cookie moved out of the config file" },
+  "log.cookieInConf":             { "配置文件中保存了cookie，建议改用环境变量或cookieFile", "the config file contains a cookie, consider an environment variable or cookieFile" },
+  "log.tokenNotSaved":            { "访问令牌不会写入新配置文件，请改用环境变量", "the serve token is not written to the new config, use an environment variable" },
+  "log.tokenInConf":              { "配置文件中保存了访问令牌，建议改用环境变量", "the config file contains the serve token, consider an environment variable" },
+  "log.migrated":                 { "配置文件已升级", "config file migrated" },
+  "log.profile":                  { "使用配置方案", "using profile" },
+  "log.unmasked":                 { "已关闭个人信息遮盖，输出内容请勿外传", "personal data masking is off, do not share this output" },
+  "log.dates":                    { "已生成调解日期", "generated mediation dates" },
+  "log.closeBook":                { "无法关闭excel数据表", "cannot close the excel workbook" },
+  "log.skipRows":                 { "跳过excel表的前若干行", "skipping leading excel rows" },
+  "log.source":                   { "开始处理数据源", "processing data source" },
+  "log.rowsSelected":             { "已按指定行号与筛选表达式选出数据行", "selected rows by row numbers and filter" },
+  "log.readRow":                  { "正在抓取excel表数据", "reading excel row" },
+  "log.rowUnreadable":            { "无法获取该行内容，将跳过该行", "cannot read this row, skipping" },
+  "log.marshal":                  { "无法序列化请求体", "cannot serialize the request body" },
+  "log.newRequest":               { "无法创建请求", "cannot create the request" },
+  "log.fake":                     { "伪请求", "fake request" },
+  "log.header":                   { "请求头", "request header" },
+  "log.body":                     { "请求体", "request body" },
+  "log.form":                     { "请求体（表单格式）", "request body (form)" },
+  "log.send":                     { "无法发送请求", "cannot send the request" },
+  "log.unknownResult":            { "新建结果未知，无法解析响应请求体，请手动确认", "result unknown, cannot read the response body, please check manually" },
+  "log.sessionExpired":           { "新建失败，请立即更新Cookie信息", "creation failed, please update the cookie now" },
+  "log.created":                  { "新建成功！", "case created" },
+  "log.retry":                    { "首次请求失败，即将重试", "first request failed, retrying" },
+  "log.retryWait":                { "已等待，再次尝试", "waited, trying again" },
+  "log.retryFailed":              { "重试失败", "retry failed" },
+  "log.rest":                     { "休息一下...", "resting..." },
+  "log.interrupting":             { "收到中断信号，当前请求完成后停止，再按一次Ctrl-C立即退出", "interrupt received, stopping after the current request; press Ctrl-C again to quit now" },
+  "log.forceExit":                { "强制退出，最后一行的新建结果未知，请手动确认", "forced exit, the result of the last row is unknown, please check manually" },
+  "log.paused":                   { "已暂停，按回车或发送SIGUSR1继续", "paused, press Enter or send SIGUSR1 to resume" },
+  "log.resumed":                  { "继续处理", "resuming" },
+  "log.watching":                 { "开始监视目录，按Ctrl-C停止", "watching the directory, press Ctrl-C to stop" },
+  "log.watchFile":                { "发现新文件，开始处理", "new file found, processing" },
+  "log.watchDuplicate":           { "该文件已处理过，不再处理，移入failed目录", "file was processed before, moving it to failed without processing" },
+  "log.watchUnreadable":          { "无法读取该文件，稍后重试", "cannot read the file, will retry later" },
+  "log.watchFailed":              { "该文件未能处理，移入failed目录", "file could not be processed, moving it to failed" },
+  "log.watchMove":                { "无法移动文件", "cannot move the file" },
+  "log.watchMoved":               { "文件已移动", "file moved" },
+  "log.watchStopped":             { "已停止监视", "stopped watching" },
+  "log.ledgerStale":              { "无法重新读取处理记录，使用上次读取的记录", "cannot reload the ledger, using the last loaded records" },
+  "log.quotaFailed":              { "无法记录每日新建数，每日上限可能不准确", "cannot record the daily count, the daily limit may be inaccurate" },
+  "log.scheduleWait":             { "不在允许提交的时间段或已达每日上限，等待", "outside the submission windows or daily limit reached, waiting" },
+  "log.serving":                  { "接口服务已启动，按Ctrl-C停止", "API server started, press Ctrl-C to stop" },
+  "log.serveStopped":             { "接口服务已停止", "API server stopped" },
+  "log.jobQueued":                { "已接收案件，排队等待提交", "case accepted and queued" },
+
+  // 进度
+  "progress.line":                { "行 %d %s %d/%d %d%% 成功 %d 失败 %d 跳过 %d 剩余约 %s", "row %d %s %d/%d %d%% ok %d failed %d skipped %d eta %s" },
+
+  // 运行汇总
+  "summary.title":                { "========== 运行汇总 ==========", "========== Run summary ==========" },
+  "summary.line":                 { "==============================", "=================================" },
+  "summary.source":               { "数据源", "Source" },
+  "summary.startedAt":            { "开始时间", "Started at" },
+  "summary.finishedAt":           { "结束时间", "Finished at" },
+  "summary.read":                 { "读取行数", "Rows read" },
+  "summary.skipped":              { "跳过行数", "Rows skipped" },
+  "summary.skipReason":           { "  跳过：%s", "  skipped: %s" },
+  "summary.interrupted":          { "已中断", "Interrupted" },
+  "summary.yes":                  { "是", "yes" },
+  "summary.exitCode":             { "退出码", "Exit code" },
+  "summary.exitCodeValue":        { "%d（%s）", "%d (%s)" },
+  "summary.invalid":              { "检查未通过", "Failed validation" },
+  "summary.submitted":            { "已提交", "Submitted" },
+  "summary.succeeded":            { "新建成功", "Succeeded" },
+  "summary.failed":               { "重试后仍失败", "Failed after retries" },
+  "summary.duration":             { "用时", "Duration" },
+  "summary.throughput":           { "吞吐量（行/分钟）", "Throughput (rows/min)" },
+  "summary.metric":               { "指标", "Metric" },
+  "summary.value":                { "数值", "Value" },
+  "summary.rowHeader":            { "行号,申请人,被申请人,状态,原因,数据源", "Row,Applicant,Respondent,Status,Reason,Source" },
+  "summary.sep":                  { "：", ": " },
+  "summary.sheet":                { "汇总", "Summary" },
+  "summary.rowsSheet":            { "明细", "Rows" },
+
+  // 预览与配置方案
+  "preview.endpoint":             { "接口", "Endpoint" },
+  "preview.header":               { "行号\t方案\t申请人\t手机号\t证件号码\t被申请人\t手机号\t固定电话\t证件号码\t案由\t调解员\t开始日期\t结束日期\t问题", "ROW\tPROFILE\tAPPLICANT\tMOBILE\tID\tRESPONDENT\tMOBILE\tLANDLINE\tID\tCAUSE\tMEDIATOR\tSTART\tEND\tISSUES" },
+  "preview.issues":               { "%d错误/%d警告", "%d errors/%d warnings" },
+  "preview.title":                { "新建案例预览", "Case preview" },
+  "preview.rows":                 { "共%d行", "%d row(s)" },
+  "preview.row":                  { "第%d行", "Row %d" },
+  "preview.cause":                { "案由", "Cause" },
+  "preview.disputeType":          { "纠纷类型", "Dispute type" },
+  "preview.mediator":             { "调解员", "Mediator" },
+  "preview.dates":                { "调解日期", "Mediation dates" },
+  "preview.to":                   { "至", "to" },
+  "preview.applicant":            { "申请人", "Applicant" },
+  "preview.respondent":           { "被申请人", "Respondent" },
+  "preview.mobile":               { "手机号", "Mobile" },
+  "preview.phones":               { "手机号/固定电话", "Mobile/landline" },
+  "preview.id":                   { "证件号码", "ID number" },
+  "preview.address":              { "地址", "Address" },
+  "preview.dispute":              { "纠纷概况", "Dispute" },
+  "preview.agreement":            { "调解方案", "Agreement" },
+  "profiles.none":                { "配置文件中没有配置方案", "the config file has no profiles" },
+  "profiles.header":              { "名称\t案由\t纠纷类型\t调解员\t覆盖项", "NAME\tCAUSE\tDISPUTE TYPE\tMEDIATOR\tOVERRIDES" },
+
+  // 交互式向导
+  "wizard.intro":                 { "逐项填写配置，直接回车保留方括号中的当前值。", "Fill in each setting, press Enter to keep the current value in brackets." },
+  "wizard.section":               { "== %s（%s）==", "== %s (%s) ==" },
+  "wizard.prompt":                { "%s（%s）[%s]：", "%s (%s) [%s]: " },
+  "wizard.retry":                 { "✗ %v，请重新输入", "✗ %v, please try again" },
+  "wizard.cookieMissing":         { "cookie文件暂不存在：%s", "the cookie file does not exist yet: %s" },
+  "wizard.yes":                   { "是", "yes" },
+  "wizard.no":                    { "否", "no" },
+
+  // 命令行帮助
+  // 退出码
+  "exit.0":                       { "全部成功", "all rows succeeded" },
+  "exit.1":                       { "配置无效", "invalid configuration" },
+  "exit.2":                       { "部分失败", "partial failure" },
+  "exit.3":                       { "会话已失效", "session expired" },
+  "exit.4":                       { "无法读取数据源", "data source unreadable" },
+  "exit.130":                     { "已中断", "interrupted" },
+
+  "help.command":                 { "程序", "NAME" },
+  "help.usage":                   { "使用", "USAGE" },
+  "help.commands":                { "命令", "COMMANDS" },
+  "help.options":                 { "选项", "OPTIONS" },
+  "app.usage":                    { "人民法院调解新建案例接口", "create mediation cases on the people's court platform" },
+  "app.usageText":                { "case 命令 [参数...]", "case command [options...]" },
+  "app.description":              { "退出码：0 全部成功，1 配置无效，2 部分失败，3 会话已失效，4 无法读取数据源，130 已中断", "exit codes: 0 all succeeded, 1 invalid configuration, 2 partial failure, 3 session expired, 4 data source unreadable, 130 interrupted" },
+  "app.argsUsage":                { "参数使用", "arguments" },
+  "cmd.init":                     { "初始化一个配置文件（默认config.json）", "create a config file (config.json by default)" },
+  "cmd.init.text":                { "case [--config 配置文件] init [--interactive] [--force] [--from 已有配置文件]", "case [--config file] init [--interactive] [--force] [--from existing-file]" },
+  "cmd.new":                      { "根据配置文件（默认config.json）, 创建新的案例", "create cases from the config file (config.json by default)" },
+  "cmd.new.text":                 { "case [--config 配置文件] new [参数...]", "case [--config file] new [options...]" },
+  "cmd.validate":                 { "检查配置文件（默认config.json）及所有数据行，不发送请求", "check the config file and every data row without sending requests" },
+  "cmd.validate.text":            { "case validate [--format table|json] [参数...]", "case validate [--format table|json] [options...]" },
+  "cmd.preview":                  { "生成指定行的完整请求体以供审核，不发送请求", "build the full request bodies for the given rows for review, without sending" },
+  "cmd.schema":                   { "输出配置文件的JSON Schema，供编辑器自动补全与校验", "print the JSON Schema of the config file for editor completion and checks" },
+  "cmd.profiles":                 { "管理配置文件中的配置方案", "manage the profiles in the config file" },
+  "cmd.profiles.list":            { "列出所有配置方案", "list all profiles" },
+  "cmd.watch":                    { "监视目录，自动检查并提交其中新的工作簿（.xlsx、.csv）", "watch a directory and check and submit new workbooks (.xlsx, .csv) dropped into it" },
+  "cmd.watch.text":               { "case [--config 配置文件] watch [参数...] 目录", "case [--config file] watch [options...] directory" },
+  "cmd.serve":                    { "启动本地接口服务，供其他程序通过HTTP提交案件并查询状态", "start a local HTTP API for other tools to submit cases and query their status" },
+  "cmd.serve.text":               { "case [--config 配置文件] serve [--addr 127.0.0.1:8765] [参数...]", "case [--config file] serve [--addr 127.0.0.1:8765] [options...]" },
+  "flag.interactive":             { "逐项询问并填写配置", "fill in the config interactively" },
+  "flag.force":                   { "覆盖已有的配置文件（原文件将备份）", "overwrite an existing config file (a backup is kept)" },
+  "flag.from":                    { "以已有配置文件为基础，保留已有的值并补全新配置项", "start from an existing config file, keeping its values and adding new keys" },
+  "flag.validateFormat":          { "输出格式：table 或 json", "output format: table or json" },
+  "flag.previewRows":             { "excel行号，如 5-12 或 17,42,90-95", "excel rows, e.g. 5-12 or 17,42,90-95" },
+  "flag.previewFormat":           { "输出格式：json、table 或 html", "output format: json, table or html" },
+  "flag.config":                  { "配置文件路径", "config file path" },
+  "flag.unmask":                  { "不遮盖个人信息（姓名、电话、证件号码、地址与cookie），仅限排查问题时使用", "do not mask personal data (names, phones, IDs, addresses and cookie), for troubleshooting only" },
+  "flag.lang":                    { "界面语言：zh-CN 或 en（默认按LANG环境变量）", "interface language: zh-CN or en (defaults to the LANG environment variable)" },
+  "flag.sheet":                   { "excel工作表名", "excel sheet name" },
+  "flag.skip":                    { "跳过行数", "number of rows to skip" },
+  "flag.count":                   { "执行行数（与--where同用时为满足条件的行数）", "number of rows to run (rows that match when used with --where)" },
+  "flag.rows":                    { "excel行号，如 17,42,90-95（优先于--skip与--count）", "excel rows, e.g. 17,42,90-95 (overrides --skip and --count)" },
+  "flag.where":                   { "按列筛选数据行，如 'F > 5000 && G == \"劳动争议\"'", "filter rows by column values, e.g. 'F > 5000 && G == \"labor\"'" },
+  "flag.fake":                    { "伪请求模式，只打印请求内容", "fake mode, only print the requests" },
+  "flag.verbose":                 { "调试模式，打印详细信息", "verbose mode, print details" },
+  "flag.delay":                   { "单次请求延迟（秒）", "delay between requests (seconds)" },
+  "flag.cookieFile":              { "从文件读取cookie字符串（\"-\"表示从标准输入读取）", "read the cookie from a file (\"-\" reads standard input)" },
+  "flag.profile":                 { "使用配置文件中的指定配置方案（profiles）", "use the named profile from the config file" },
+  "flag.addr":                    { "监听地址，覆盖配置文件中的serve.addr", "listen address, overrides serve.addr in the config file" },
+}
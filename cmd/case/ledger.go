@@ -0,0 +1,312 @@
+package main
+
+import (
+  "io"
+  "os"
+  "sync"
+  "time"
+  "errors"
+  "io/ioutil"
+  "crypto/sha256"
+  "encoding/hex"
+  "encoding/json"
+)
+
+const (
+  // 默认处理记录文件
+  LEDGER_FILE = "ledger.json"
+
+  // 每日新建数保留的天数
+  LEDGER_QUOTA_DAYS = 31
+)
+
+// 工作簿处理状态
+const (
+  FILE_PROCESSING = "processing"
+  FILE_PROCESSED  = "processed"
+  FILE_FAILED     = "failed"
+)
+
+// 该文件已开始提交（可能由另一个进程提交），不应再次提交
+var ErrFileClaimed = NewError("err.fileClaimed")
+
+// 处理记录：按内容摘要记录已处理的工作簿，每次变化后立即写回文件，
+// 程序重启后仍能识别已处理（或处理中途退出）的文件；多个进程（如同时运行的
+// case watch与case new）可共用同一文件，修改时加文件锁并以文件中的记录为准
+type Ledger struct {
+  mu          sync.Mutex
+  path        string
+
+  // 内容摘要（sha256） -> 处理记录
+  Files       map[string]*LedgerFile  `json:"files"`
+
+  // 每日新建数（日期 -> 账号或调解员 -> 数量），见提交时间安排
+  Quota       map[string]map[string]int `json:"quota,omitempty"`
+}
+
+// 单个工作簿的处理记录
+type LedgerFile struct {
+  // 文件名
+  Name        string          `json:"name"`
+
+  // 使用的配置方案
+  Profile     string          `json:"profile,omitempty"`
+
+  State       string          `json:"state"`
+  ExitCode    int             `json:"exitCode"`
+
+  // 已提交的行数
+  Submitted   int             `json:"submitted"`
+
+  // 失败原因
+  Reason      string          `json:"reason,omitempty"`
+
+  // 结果文件（运行报告、结果工作簿或检查问题）
+  Results     []string        `json:"results,omitempty"`
+
+  StartedAt   time.Time       `json:"startedAt"`
+  FinishedAt  time.Time       `json:"finishedAt"`
+}
+
+// 是否已开始提交（处理中、已处理或提交过行），此时同一文件不再处理；
+// 只在检查阶段失败的文件可在修正配置后重新放入
+func (f *LedgerFile) Blocks() bool {
+  return f.State == FILE_PROCESSING || f.State == FILE_PROCESSED || f.Submitted > 0
+}
+
+// 打开处理记录，文件不存在时为空记录
+func OpenLedger(path string) (*Ledger, error) {
+  l := &Ledger{ path: path }
+  if err := l.reload(); err != nil {
+    return nil, err
+  }
+
+  return l, nil
+}
+
+// 重新读取文件中的记录（包括其他进程写入的记录），文件不存在时为空记录
+func (l *Ledger) reload() error {
+  doc := struct {
+    Files     map[string]*LedgerFile    `json:"files"`
+    Quota     map[string]map[string]int `json:"quota"`
+  }{}
+
+  data, err := ioutil.ReadFile(l.path)
+  if err != nil && !errors.Is(err, os.ErrNotExist) {
+    return NewError("err.ledger", l.path, err)
+  }
+
+  if err == nil {
+    if err := json.Unmarshal(data, &doc); err != nil {
+      return NewError("err.ledger", l.path, err)
+    }
+  }
+
+  if doc.Files == nil {
+    doc.Files = map[string]*LedgerFile{}
+  }
+
+  if doc.Quota == nil {
+    doc.Quota = map[string]map[string]int{}
+  }
+
+  l.Files, l.Quota = doc.Files, doc.Quota
+  return nil
+}
+
+// 加文件锁后重新读取记录，修改后写回，以免覆盖其他进程写入的记录
+func (l *Ledger) update(fn func()) error {
+  l.mu.Lock()
+  defer l.mu.Unlock()
+
+  lock, err := os.OpenFile(l.path + ".lock", os.O_CREATE | os.O_RDWR, 0644)
+  if err != nil {
+    return NewError("err.ledger", l.path, err)
+  }
+  defer lock.Close()
+
+  if err := lockFile(lock); err != nil {
+    return NewError("err.ledger", l.path, err)
+  }
+  defer unlockFile(lock)
+
+  if err := l.reload(); err != nil {
+    return err
+  }
+
+  fn()
+  return l.save()
+}
+
+// 读取前重新读取记录，读取失败时使用上次的记录
+func (l *Ledger) refresh() {
+  if err := l.reload(); err != nil {
+    Logger.Warn(T("log.ledgerStale"), "err", err)
+  }
+}
+
+// 查找处理记录，未处理过时为空
+func (l *Ledger) File(digest string) *LedgerFile {
+  l.mu.Lock()
+  defer l.mu.Unlock()
+
+  l.refresh()
+  if f, ok := l.Files[digest]; ok {
+    c := *f
+    return &c
+  }
+
+  return nil
+}
+
+// 开始提交前认领该文件：在文件锁内检查并记录，已开始提交（见Blocks）时返回ErrFileClaimed，
+// 以免多个进程同时提交同一文件；返回错误时不应提交该文件
+func (l *Ledger) Begin(digest string, name string, profile string) error {
+  claimed := false
+  err := l.update(func() {
+    if f, ok := l.Files[digest]; ok && f.Blocks() {
+      claimed = true
+      return
+    }
+
+    l.Files[digest] = &LedgerFile{
+      Name:       name,
+      Profile:    profile,
+      State:      FILE_PROCESSING,
+      StartedAt:  time.Now(),
+    }
+  })
+
+  if err == nil && claimed {
+    return ErrFileClaimed
+  }
+  return err
+}
+
+// 提交结束后记录结果（名称、状态、退出码、提交行数、原因与结果文件）
+func (l *Ledger) Finish(digest string, res LedgerFile) error {
+  return l.update(func() {
+    res.StartedAt = time.Now()
+    if f, ok := l.Files[digest]; ok {
+      res.StartedAt = f.StartedAt
+    }
+    res.FinishedAt = time.Now()
+    l.Files[digest] = &res
+  })
+}
+
+// 检查未通过时记录，已开始提交的记录（可能由另一个进程认领）不覆盖
+func (l *Ledger) Reject(digest string, res LedgerFile) error {
+  return l.update(func() {
+    if f, ok := l.Files[digest]; ok && f.Blocks() {
+      return
+    }
+
+    res.StartedAt = time.Now()
+    res.FinishedAt = res.StartedAt
+    l.Files[digest] = &res
+  })
+}
+
+// 某天已新建的数量，day如 2006-01-02
+func (l *Ledger) Used(day string, key string) int {
+  l.mu.Lock()
+  defer l.mu.Unlock()
+
+  l.refresh()
+  return l.Quota[day][key]
+}
+
+// 在文件锁内预留每日新建数：各键（账号或调解员）均未达上限（0为不限）时全部加一并返回true，
+// 否则不计数；多个进程共用处理记录时也不会超过上限
+func (l *Ledger) Reserve(day string, limits map[string]int) (bool, error) {
+  reserved := false
+  err := l.update(func() {
+    for key, limit := range limits {
+      if limit > 0 && l.Quota[day][key] >= limit {
+        return
+      }
+    }
+
+    if l.Quota[day] == nil {
+      l.Quota[day] = map[string]int{}
+    }
+
+    for key := range limits {
+      l.Quota[day][key]++
+    }
+    l.prune(day)
+    reserved = true
+  })
+
+  return reserved && err == nil, err
+}
+
+// 释放预留的新建数（提交明确失败时）
+func (l *Ledger) Release(day string, keys ...string) error {
+  return l.update(func() {
+    for _, key := range keys {
+      if l.Quota[day][key] > 0 {
+        l.Quota[day][key]--
+      }
+    }
+  })
+}
+
+// 清除过早的每日新建数
+func (l *Ledger) prune(day string) {
+  t, err := time.ParseInLocation(DAY_FORMAT, day, time.Local)
+  if err != nil {
+    return
+  }
+
+  oldest := t.AddDate(0, 0, -LEDGER_QUOTA_DAYS).Format(DAY_FORMAT)
+  for d := range l.Quota {
+    if d < oldest {
+      delete(l.Quota, d)
+    }
+  }
+}
+
+// 先写入临时文件再替换，以免写入中途退出时损坏记录；只在持有文件锁时调用
+func (l *Ledger) save() error {
+  data, err := json.MarshalIndent(l, "", "  ")
+  if err != nil {
+    return err
+  }
+
+  tmp := l.path + ".tmp"
+  if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+    return NewError("err.ledger", l.path, err)
+  }
+
+  if err := os.Rename(tmp, l.path); err != nil {
+    return NewError("err.ledger", l.path, err)
+  }
+
+  return nil
+}
+
+// 文件内容摘要（sha256，十六进制）
+func FileDigest(path string) (string, error) {
+  f, err := os.Open(path)
+  if err != nil {
+    return "", err
+  }
+  defer f.Close()
+
+  h := sha256.New()
+  if _, err := io.Copy(h, f); err != nil {
+    return "", err
+  }
+
+  return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// 处理记录文件路径，未配置时为默认文件
+func (conf *GlobalConfig) LedgerPath() string {
+  if conf.Ledger == "" {
+    return LEDGER_FILE
+  }
+  return conf.Ledger
+}
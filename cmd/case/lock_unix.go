@@ -0,0 +1,17 @@
+//go:build !windows
+
+package main
+
+import (
+  "os"
+  "syscall"
+)
+
+// 对文件加排他锁，其他进程加锁时等待
+func lockFile(f *os.File) error {
+  return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+func unlockFile(f *os.File) error {
+  return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package main
+
+import (
+  "os"
+
+  "golang.org/x/sys/windows"
+)
+
+// 对文件加排他锁，其他进程加锁时等待
+func lockFile(f *os.File) error {
+  return windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, &windows.Overlapped{})
+}
+
+func unlockFile(f *os.File) error {
+  return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, &windows.Overlapped{})
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+  "io"
+  "os"
+  "sync"
+  "context"
+  "strings"
+  "log/slog"
+
+  "gopkg.in/natefinch/lumberjack.v2"
+)
+
+// 日志格式
+const (
+  LOG_TEXT = "text"
+  LOG_JSON = "json"
+)
+
+// 控制台日志输出
+var console = &consoleWriter{ w: os.Stderr }
+
+// 全局日志，加载配置前只输出到控制台
+var Logger = slog.New(newLogHandler(console, LOG_TEXT, slog.LevelInfo))
+
+// 日志文件，重新配置时关闭
+var logFile io.Closer
+
+// 解析日志级别，空字符串为warn
+func ParseLogLevel(s string) (slog.Level, error) {
+  switch strings.ToLower(s) {
+  case "debug":
+    return slog.LevelDebug, nil
+  case "info":
+    return slog.LevelInfo, nil
+  case "", "warn":
+    return slog.LevelWarn, nil
+  case "error":
+    return slog.LevelError, nil
+  }
+
+  return 0, NewError("err.logLevel", s)
+}
+
+func newLogHandler(w io.Writer, format string, level slog.Level) slog.Handler {
+  opts := &slog.HandlerOptions{ Level: level, ReplaceAttr: maskAttr }
+  if format == LOG_JSON {
+    return slog.NewJSONHandler(w, opts)
+  }
+
+  return slog.NewTextHandler(w, opts)
+}
+
+// 按调试配置设置全局日志：控制台输出info（调试模式为debug）及以上，
+// 日志文件按logLevel记录并自动轮转
+func SetupLogger(debug *DebugConfig) error {
+  if logFile != nil {
+    logFile.Close()
+    logFile = nil
+  }
+
+  if debug == nil {
+    Logger = slog.New(newLogHandler(console, LOG_TEXT, slog.LevelInfo))
+    return nil
+  }
+
+  format := debug.LogFormat
+  if format == "" {
+    format = LOG_TEXT
+  }
+
+  level := slog.LevelInfo
+  if debug.Verbose {
+    level = slog.LevelDebug
+  }
+
+  handlers := teeHandler{ newLogHandler(console, format, level) }
+
+  if debug.LogPath != "" {
+    fileLevel, err := ParseLogLevel(debug.LogLevel)
+    if err != nil {
+      return err
+    }
+
+    f := &lumberjack.Logger{
+      Filename:   debug.LogPath,
+      MaxSize:    debug.LogMaxSize,
+      MaxBackups: debug.LogMaxBackups,
+      MaxAge:     debug.LogMaxAge,
+    }
+    logFile = f
+    handlers = append(handlers, newLogHandler(f, format, fileLevel))
+  }
+
+  Logger = slog.New(handlers)
+  return nil
+}
+
+// 按遮盖策略遮盖日志中的当事人姓名
+func maskAttr(groups []string, a slog.Attr) slog.Attr {
+  switch a.Key {
+  case "applicant", "respondent":
+    return slog.String(a.Key, Masking.Name(a.Value.String()))
+  }
+
+  return a
+}
+
+// 带数据行上下文的日志
+func RowLogger(data *DataConfig, line int) *slog.Logger {
+  lg := Logger.With("row", line)
+  if data != nil {
+    lg = lg.With("sheet", data.Sheet)
+  }
+
+  return lg
+}
+
+// 控制台输出，显示进度条时先清除进度行，输出后重新绘制
+type consoleWriter struct {
+  mu        sync.Mutex
+  w         io.Writer
+  progress  *Progress
+}
+
+// 设置（或以nil取消）控制台上的进度显示
+func (c *consoleWriter) SetProgress(p *Progress) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+  c.progress = p
+}
+
+func (c *consoleWriter) Write(b []byte) (int, error) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  if c.progress != nil {
+    c.progress.clear()
+    defer c.progress.redraw()
+  }
+
+  return c.w.Write(b)
+}
+
+// 同时写入多个输出的日志处理器
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+  for _, h := range t {
+    if h.Enabled(ctx, level) {
+      return true
+    }
+  }
+
+  return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+  var first error
+  for _, h := range t {
+    if !h.Enabled(ctx, r.Level) {
+      continue
+    }
+
+    if err := h.Handle(ctx, r.Clone()); err != nil && first == nil {
+      first = err
+    }
+  }
+
+  return first
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+  hs := make(teeHandler, len(t))
+  for i, h := range t {
+    hs[i] = h.WithAttrs(attrs)
+  }
+
+  return hs
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+  hs := make(teeHandler, len(t))
+  for i, h := range t {
+    hs[i] = h.WithGroup(name)
+  }
+
+  return hs
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+  "os"
+  "fmt"
+  "strings"
+
+  "github.com/urfave/cli/v2"
+)
+
+const (
+  CONFIG_FILE = "config.json"
+)
+
+// 初始化默认配置
+func initCase(ctx *cli.Context) error {
+  path := ctx.String("config")
+  from := ctx.String("from")
+
+  // 已有配置文件时，除非指定--force或原地补全（--from为同一文件），否则拒绝覆盖
+  if _, err := os.Stat(path); err == nil {
+    if !ctx.Bool("force") && !sameFile(from, path) {
+      return NewError("err.confExists", path, path)
+    }
+
+    backup, err := BackupFile(path)
+    if err != nil {
+      return NewError("err.backup", err)
+    }
+    Logger.Info(T("log.backup"), "backup", backup)
+  }
+
+  Conf = InitConf()
+  if from != "" {
+    version, err := MergeConf(Conf, from)
+    if err != nil {
+      return err
+    }
+
+    if req := Conf.Request; req != nil && req.Cookie != "" && req.CookieFile == "" {
+      if version == 0 {
+        if err := MoveCookie(req, path); err != nil {
+          return err
+        }
+      } else {
+        Logger.Warn(T("log.cookieNotSaved"), "config", from, "env", EnvName("request", "cookie"))
+      }
+    }
+
+    if Conf.Serve != nil && Conf.Serve.Token != "" {
+      Logger.Warn(T("log.tokenNotSaved"), "config", from, "env", EnvName("serve", "token"))
+    }
+
+    if version < CONFIG_VERSION {
+      Logger.Info(T("log.migrated"), "from", version, "to", CONFIG_VERSION)
+    }
+  }
+
+  if ctx.Bool("interactive") {
+    if err := NewWizard(os.Stdin, os.Stdout).Run(Conf); err != nil {
+      return err
+    }
+  }
+
+  if err := SaveConf(path); err != nil {
+    return err
+  }
+
+  if ctx.Bool("interactive") || from != "" {
+    fmt.Printf("\n%s\n", T("log.saved", path))
+  }
+  return nil
+}
+
+// 调用接口新建案例
+func newCase(ctx *cli.Context) error {
+  if err := loadConf(ctx); err != nil {
+    return err
+  }
+
+  issues := PreCheck(Conf)
+  issues.Log(Logger)
+  if issues.HasError() {
+    return NewError("err.precheck")
+  }
+
+  cases, err := NewCaseSet(Conf)
+  if err != nil {
+    return err
+  }
+
+  sources, err := Conf.Data.Expand()
+  if err != nil {
+    return err
+  }
+
+  ledger, err := OpenLedger(Conf.LedgerPath())
+  if err != nil {
+    return err
+  }
+
+  control := NewControl(ctx.Context, StdinIsTerminal())
+  defer control.Stop()
+
+  summary, err := RunBatch(Conf, cases, sources, control, ledger)
+  if summary == nil {
+    return err
+  }
+
+  summary.Print(os.Stdout)
+  if files, err := summary.Save(REPORT_DIR); err != nil {
+    Logger.Error(T("log.reportFailed"), "err", err)
+  } else {
+    Logger.Info(T("log.reportSaved"), "files", strings.Join(files, ", "))
+  }
+
+  return err
+}
+
+// 命令行程序
+func newApp() *cli.App {
+  cli.CommandHelpTemplate = T("help.command") + `:
+   {{.HelpName}} - {{if .Description}}{{.Description}}{{else}}{{.Usage}}{{end}}
+` + T("help.usage") + `:
+   {{if .UsageText}}{{.UsageText}}{{else}}{{.HelpName}} command{{if .VisibleFlags}} [command options]{{end}} {{if .ArgsUsage}}{{.ArgsUsage}}{{else}}[arguments...]{{end}}{{end}}
+` + T("help.commands") + `:{{range .VisibleCategories}}{{if .Name}}
+   {{.Name}}:{{end}}{{range .VisibleCommands}}
+     {{join .Names ", "}}{{"\t"}}{{.Usage}}{{end}}
+{{end}}{{if .VisibleFlags}}
+` + T("help.options") + `:
+   {{range .VisibleFlags}}{{.}}
+   {{end}}{{end}}
+`
+
+  app := cli.NewApp()
+	app.Usage = T("app.usage")
+	app.UsageText = T("app.usageText")
+	app.Description = T("app.description")
+	app.ArgsUsage = T("app.argsUsage")
+	app.EnableBashCompletion = true
+	app.HideVersion = true
+  app.Flags = globalFlags
+  app.Commands = []*cli.Command{
+    &cli.Command{
+      Name: "init",
+      Usage: T("cmd.init"),
+      UsageText: T("cmd.init.text"),
+      Flags: []cli.Flag{
+        &cli.BoolFlag{
+          Name: "interactive",
+          Aliases: []string{ "i" },
+          Usage: T("flag.interactive"),
+        },
+        &cli.BoolFlag{
+          Name: "force",
+          Aliases: []string{ "f" },
+          Usage: T("flag.force"),
+        },
+        &cli.StringFlag{
+          Name: "from",
+          Usage: T("flag.from"),
+        },
+      },
+      Action: initCase,
+    },
+    &cli.Command{
+      Name: "new",
+      Usage: T("cmd.new"),
+      UsageText: T("cmd.new.text"),
+      Flags: append(append([]cli.Flag{ profileFlag }, dataFlags...), requestFlags...),
+      Action: newCase,
+    },
+    &cli.Command{
+      Name: "watch",
+      Usage: T("cmd.watch"),
+      UsageText: T("cmd.watch.text"),
+      Flags: append([]cli.Flag{ profileFlag }, requestFlags...),
+      Action: watchCase,
+    },
+    &cli.Command{
+      Name: "serve",
+      Usage: T("cmd.serve"),
+      UsageText: T("cmd.serve.text"),
+      Flags: append([]cli.Flag{
+        profileFlag,
+        &cli.StringFlag{
+          Name: "addr",
+          Usage: T("flag.addr"),
+        },
+      }, requestFlags...),
+      Action: serveCase,
+    },
+    &cli.Command{
+      Name: "validate",
+      Usage: T("cmd.validate"),
+      UsageText: T("cmd.validate.text"),
+      Flags: append([]cli.Flag{
+        profileFlag,
+        &cli.StringFlag{
+          Name: "format",
+          Value: "table",
+          Usage: T("flag.validateFormat"),
+        },
+      }, dataFlags...),
+      Action: validateCase,
+    },
+    &cli.Command{
+      Name: "preview",
+      Usage: T("cmd.preview"),
+      UsageText: "case preview --rows 5-12 [--format json|table|html]",
+      Flags: []cli.Flag{
+        profileFlag,
+        &cli.StringFlag{
+          Name: "rows",
+          Usage: T("flag.previewRows"),
+          Required: true,
+        },
+        &cli.StringFlag{
+          Name: "where",
+          Usage: T("flag.where"),
+        },
+        &cli.StringFlag{
+          Name: "format",
+          Value: "json",
+          Usage: T("flag.previewFormat"),
+        },
+      },
+      Action: previewCase,
+    },
+    &cli.Command{
+      Name: "schema",
+      Usage: T("cmd.schema"),
+      UsageText: "case schema > config.schema.json",
+      Action: printSchema,
+    },
+    &cli.Command{
+      Name: "profiles",
+      Usage: T("cmd.profiles"),
+      UsageText: "case profiles list",
+      Subcommands: []*cli.Command{
+        &cli.Command{
+          Name: "list",
+          Usage: T("cmd.profiles.list"),
+          UsageText: "case profiles list",
+          Action: listProfiles,
+        },
+      },
+    },
+  }
+
+  return app
+}
+
+func main() {
+  err := newApp().Run(os.Args)
+  code := ExitCode(err)
+  switch code {
+  case EXIT_OK:
+    return
+  case EXIT_INTERRUPTED, EXIT_PARTIAL:
+    Logger.Warn(err.Error(), "exit", code)
+  default:
+    Logger.Error(err.Error(), "exit", code)
+  }
+
+  os.Exit(code)
+}
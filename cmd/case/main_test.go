@@ -0,0 +1,311 @@
+package main
+
+import (
+  "io"
+  "os"
+  "sync"
+  "strings"
+  "errors"
+  "testing"
+  "net/http"
+  "encoding/json"
+  "path/filepath"
+  "net/http/httptest"
+
+  "github.com/NataRich/auto-case/court"
+)
+
+// 新建案例接口的替身，记录收到的请求体并返回固定响应
+type fakeCourt struct {
+  mu       sync.Mutex
+  response string
+  bodies   []*CaseBody
+}
+
+func (f *fakeCourt) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+  body := &CaseBody{}
+  if err := json.Unmarshal([]byte(r.PostFormValue("mediationFormStr")), body); err != nil {
+    http.Error(w, err.Error(), http.StatusBadRequest)
+    return
+  }
+
+  f.mu.Lock()
+  f.bodies = append(f.bodies, body)
+  f.mu.Unlock()
+
+  io.WriteString(w, f.response)
+}
+
+// 在临时目录中生成数据表与配置文件，返回配置文件路径；工作目录切换到临时目录以写入运行报告
+func setupRun(t *testing.T, endpoint string, rows [][]interface{}) string {
+  dir := t.TempDir()
+
+  Conf = validConf()
+  Conf.Data.Path = writeBook(t, rows)
+  Conf.Data.SkipHeader = true
+  Conf.Data.ExecCount = len(rows) - 1
+  Conf.Data.Mapper["applicant.tel"] = "C"
+  Conf.Request.Endpoint = endpoint
+  Conf.Request.Delay = 0
+  Conf.Request.Retry = 1
+  Conf.Debug.Verbose = false
+  Conf.Debug.LogPath = filepath.Join(dir, "error.log")
+
+  path := filepath.Join(dir, CONFIG_FILE)
+  if err := SaveConf(path); err != nil {
+    t.Fatal(err)
+  }
+
+  // cookie不会写入配置文件
+  t.Setenv(EnvName("request", "cookie"), "JSESSIONID=abc")
+
+  wd, err := os.Getwd()
+  if err != nil {
+    t.Fatal(err)
+  }
+  if err := os.Chdir(dir); err != nil {
+    t.Fatal(err)
+  }
+  t.Cleanup(func() { os.Chdir(wd) })
+
+  return path
+}
+
+// 读取临时目录中唯一的运行报告
+func readSummary(t *testing.T) *Summary {
+  files, err := filepath.Glob(filepath.Join(REPORT_DIR, "run-*.json"))
+  if err != nil || len(files) != 1 {
+    t.Fatalf("reports: %v, %v", files, err)
+  }
+
+  data, err := os.ReadFile(files[0])
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  s := &Summary{}
+  if err := json.Unmarshal(data, s); err != nil {
+    t.Fatal(err)
+  }
+  return s
+}
+
+func TestNewCase(t *testing.T) {
+  fc := &fakeCourt{ response: `{"code":"1"}` }
+  srv := httptest.NewServer(fc)
+  defer srv.Close()
+
+  path := setupRun(t, srv.URL, [][]interface{}{
+    { "申请人", "被申请人", "申请人电话" },
+    { "张三", "李四", "138 0000 1111" },
+    { },
+    { "王五", "", "13800002222" },
+    { "赵六", "钱七", "+86 13800003333" },
+  })
+
+  // 第4行缺少被申请人，属于部分失败
+  err := newApp().Run([]string{ "case", "--config", path, "new" })
+  if err != ErrPartialFailure {
+    t.Fatalf("err = %v, want %v", err, ErrPartialFailure)
+  }
+
+  if len(fc.bodies) != 2 {
+    t.Fatalf("court received %d cases, want 2", len(fc.bodies))
+  }
+
+  want := [][3]string{
+    { "张三", "李四", "13800001111" },
+    { "赵六", "钱七", "13800003333" },
+  }
+  for i, body := range fc.bodies {
+    app, res := body.ApplicantList[0], body.RespondentList[0]
+    got := [3]string{ app.Name, res.Name, app.Tel }
+    if got != want[i] {
+      t.Errorf("case %d = %v, want %v", i, got, want[i])
+    }
+
+    if body.StartTime == "" || body.StartTime >= body.EndTime {
+      t.Errorf("case %d dates: %q - %q", i, body.StartTime, body.EndTime)
+    }
+  }
+
+  s := readSummary(t)
+  if s.Read != 4 || s.Skipped != 1 || s.Invalid != 1 || s.Succeeded != 2 || s.Failed != 0 {
+    t.Errorf("summary read %d, skipped %d, invalid %d, succeeded %d, failed %d",
+             s.Read, s.Skipped, s.Invalid, s.Succeeded, s.Failed)
+  }
+
+  if len(s.Rows) != 4 || s.Rows[2].Row != 4 || s.Rows[2].Status != ROW_INVALID {
+    t.Errorf("rows: %+v", s.Rows)
+  }
+
+  if s.ExitCode != EXIT_PARTIAL {
+    t.Errorf("exit code = %d, want %d", s.ExitCode, EXIT_PARTIAL)
+  }
+}
+
+func TestNewCaseSessionExpired(t *testing.T) {
+  fc := &fakeCourt{ response: "<html>login</html>" }
+  srv := httptest.NewServer(fc)
+  defer srv.Close()
+
+  path := setupRun(t, srv.URL, [][]interface{}{
+    { "申请人", "被申请人", "申请人电话" },
+    { "张三", "李四", "13800001111" },
+    { "赵六", "钱七", "13800003333" },
+  })
+
+  err := newApp().Run([]string{ "case", "--config", path, "new" })
+  if !errors.Is(err, court.ErrSessionExpired) {
+    t.Fatalf("err = %v, want %v", err, court.ErrSessionExpired)
+  }
+
+  // 会话失效时不重试，也不继续提交后续各行
+  if len(fc.bodies) != 1 {
+    t.Errorf("court received %d cases, want 1", len(fc.bodies))
+  }
+
+  s := readSummary(t)
+  if s.Submitted != 1 || s.Failed != 1 || s.ExitCode != EXIT_SESSION {
+    t.Errorf("summary submitted %d, failed %d, exit code %d", s.Submitted, s.Failed, s.ExitCode)
+  }
+}
+
+func TestNewCaseDataUnreadable(t *testing.T) {
+  path := setupRun(t, "http://127.0.0.1:1", [][]interface{}{
+    { "申请人", "被申请人", "申请人电话" },
+    { "张三", "李四", "13800001111" },
+  })
+
+  if err := os.Remove(Conf.Data.Path); err != nil {
+    t.Fatal(err)
+  }
+
+  err := newApp().Run([]string{ "case", "--config", path, "new" })
+  if code := ExitCode(err); code != EXIT_DATA {
+    t.Errorf("exit code = %d (%v), want %d", code, err, EXIT_DATA)
+  }
+}
+
+func TestNewCaseSelectedRows(t *testing.T) {
+  fc := &fakeCourt{ response: `{"code":"1"}` }
+  srv := httptest.NewServer(fc)
+  defer srv.Close()
+
+  path := setupRun(t, srv.URL, [][]interface{}{
+    { "申请人", "被申请人", "申请人电话", "金额" },
+    { "张三", "李四", "13800001111", 3000 },
+    { "王五", "赵六", "13800002222", 8000 },
+    { "孙七", "周八", "13800003333", 9000 },
+    { "吴九", "郑十", "13800004444", 6000 },
+  })
+
+  err := newApp().Run([]string{ "case", "--config", path, "new", "--rows", "2,4-5", "--where", "D > 5000" })
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  var names []string
+  for _, body := range fc.bodies {
+    names = append(names, body.ApplicantList[0].Name)
+  }
+
+  if strings.Join(names, ",") != "孙七,吴九" {
+    t.Errorf("submitted %v, want 孙七,吴九", names)
+  }
+}
+
+func TestValidateAllRows(t *testing.T) {
+  path := setupRun(t, "http://127.0.0.1:1", [][]interface{}{
+    { "申请人", "被申请人", "申请人电话" },
+    { "张三", "李四", "13800001111" },
+    { "王五", "", "13800002222" },
+  })
+
+  // 执行行数只影响提交，检查时仍读取所有数据行
+  Conf.Data.ExecCount = 1
+  if err := SaveConf(path); err != nil {
+    t.Fatal(err)
+  }
+
+  if err := newApp().Run([]string{ "case", "--config", path, "validate" }); err == nil {
+    t.Error("validate passed with an invalid third row")
+  }
+
+  if err := newApp().Run([]string{ "case", "--config", path, "validate", "--count", "1" }); err != nil {
+    t.Errorf("validate --count 1: %v", err)
+  }
+}
+
+func TestNewCaseWhere(t *testing.T) {
+  fc := &fakeCourt{ response: `{"code":"1"}` }
+  srv := httptest.NewServer(fc)
+  defer srv.Close()
+
+  path := setupRun(t, srv.URL, [][]interface{}{
+    { "申请人", "被申请人", "申请人电话", "金额" },
+    { "张三", "李四", "13800001111", 3000 },
+    { "王五", "赵六", "13800002222", 8000 },
+    { "孙七", "周八", "13800003333", 4000 },
+    { "吴九", "郑十", "13800004444", 6000 },
+    { "冯一", "陈二", "13800005555", 7000 },
+  })
+
+  // 执行行数按满足条件的行计，首个数据行不满足条件
+  err := newApp().Run([]string{ "case", "--config", path, "new", "--count", "2", "--where", "D > 5000" })
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  var names []string
+  for _, body := range fc.bodies {
+    names = append(names, body.ApplicantList[0].Name)
+  }
+
+  if strings.Join(names, ",") != "王五,吴九" {
+    t.Errorf("submitted %v, want 王五,吴九", names)
+  }
+}
+
+func TestNewCaseSources(t *testing.T) {
+  fc := &fakeCourt{ response: `{"code":"1"}` }
+  srv := httptest.NewServer(fc)
+  defer srv.Close()
+
+  path := setupRun(t, srv.URL, [][]interface{}{
+    { "申请人", "被申请人", "申请人电话" },
+    { "张三", "李四", "13800001111" },
+  })
+
+  // 第二个工作簿没有列名行，电话在D列
+  other := writeBook(t, [][]interface{}{
+    { "王五", "赵六", "", "13800002222" },
+    { "孙七", "周八", "", "13800003333" },
+  })
+
+  Conf.Data.Sources = []json.RawMessage{
+    json.RawMessage(`{ "path": "` + filepath.ToSlash(Conf.Data.Path) + `" }`),
+    json.RawMessage(`{ "path": "` + filepath.ToSlash(other) + `", "skipHeader": false, "execCount": 5, "mapper": { "applicant.tel": "D" } }`),
+  }
+  if err := SaveConf(path); err != nil {
+    t.Fatal(err)
+  }
+
+  if err := newApp().Run([]string{ "case", "--config", path, "new" }); err != nil {
+    t.Fatal(err)
+  }
+
+  var got []string
+  for _, body := range fc.bodies {
+    got = append(got, body.ApplicantList[0].Name + ":" + body.ApplicantList[0].Tel)
+  }
+
+  if strings.Join(got, ",") != "张三:13800001111,王五:13800002222,孙七:13800003333" {
+    t.Errorf("submitted %v", got)
+  }
+
+  s := readSummary(t)
+  if s.Succeeded != 3 || len(s.Rows) != 3 || s.Rows[0].Source == s.Rows[2].Source {
+    t.Errorf("summary succeeded %d, rows %+v", s.Succeeded, s.Rows)
+  }
+}
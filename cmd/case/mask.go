@@ -0,0 +1,190 @@
+package main
+
+import (
+  "fmt"
+  "strings"
+  "unicode"
+  "crypto/sha256"
+)
+
+// 当前遮盖策略，nil为全部遮盖，加载配置时设置
+var Masking *MaskConfig
+
+// 默认遮盖策略：全部遮盖
+func DefaultMask() *MaskConfig {
+  return &MaskConfig{
+    Names:      true,
+    Phones:     true,
+    IDCards:    true,
+    Addresses:  true,
+    Cookies:    true,
+  }
+}
+
+// 不遮盖任何信息（须显式指定--unmask）
+func NoMask() *MaskConfig {
+  return &MaskConfig{}
+}
+
+func (m *MaskConfig) policy() *MaskConfig {
+  if m == nil {
+    return DefaultMask()
+  }
+
+  return m
+}
+
+// 遮盖姓名，保留首尾字，如 张*三、李*
+func (m *MaskConfig) Name(name string) string {
+  if !m.policy().Names {
+    return name
+  }
+
+  return MaskName(name)
+}
+
+// 遮盖电话号码
+func (m *MaskConfig) Phone(phone string) string {
+  if !m.policy().Phones {
+    return phone
+  }
+
+  return MaskPhone(phone)
+}
+
+// 遮盖证件号码
+func (m *MaskConfig) IDCard(id string) string {
+  if !m.policy().IDCards {
+    return id
+  }
+
+  return MaskIDCard(id)
+}
+
+// 遮盖地址
+func (m *MaskConfig) Address(addr string) string {
+  if !m.policy().Addresses {
+    return addr
+  }
+
+  return MaskAddress(addr)
+}
+
+// 以摘要代替cookie
+func (m *MaskConfig) Cookie(cookie string) string {
+  if !m.policy().Cookies {
+    return cookie
+  }
+
+  return MaskCookie(cookie)
+}
+
+// 遮盖姓名，保留首尾字，如 张*三、李*
+func MaskName(name string) string {
+  r := []rune(strings.TrimSpace(name))
+  switch len(r) {
+  case 0:
+    return ""
+  case 1:
+    return "*"
+  case 2:
+    return string(r[:1]) + "*"
+  }
+
+  return string(r[:1]) + strings.Repeat("*", len(r) - 2) + string(r[len(r) - 1:])
+}
+
+// 遮盖电话号码，保留前3位（固定电话为区号）与后4位数字（不足8位时只保留后2位），
+// 分隔符不变，如 138****1234、0571-***4567
+func MaskPhone(phone string) string {
+  digits := 0
+  for _, c := range phone {
+    if unicode.IsDigit(c) {
+      digits++
+    }
+  }
+
+  head, tail := 3, 4
+  if area, _, ok := strings.Cut(phone, "-"); ok {
+    head = len(area)
+  }
+
+  if digits < 8 {
+    head, tail = 0, 2
+  }
+
+  var b strings.Builder
+  n := 0
+  for _, c := range phone {
+    if !unicode.IsDigit(c) {
+      b.WriteRune(c)
+      continue
+    }
+
+    if n < head || n >= digits - tail {
+      b.WriteRune(c)
+    } else {
+      b.WriteRune('*')
+    }
+    n++
+  }
+
+  return b.String()
+}
+
+// 遮盖证件号码，保留前6位与后4位，如 110101********1234
+func MaskIDCard(id string) string {
+  r := []rune(id)
+  if len(r) <= 10 {
+    return strings.Repeat("*", len(r))
+  }
+
+  return string(r[:6]) + strings.Repeat("*", len(r) - 10) + string(r[len(r) - 4:])
+}
+
+// 遮盖地址，只保留前6个字
+func MaskAddress(addr string) string {
+  r := []rune(addr)
+  if len(r) <= 6 {
+    return addr
+  }
+
+  return string(r[:6]) + "****"
+}
+
+// 以摘要代替cookie，便于比对是否为同一会话而不泄露内容
+func MaskCookie(cookie string) string {
+  if cookie == "" {
+    return ""
+  }
+
+  sum := sha256.Sum256([]byte(cookie))
+  return fmt.Sprintf("sha256:%x", sum[:6])
+}
+
+// 按遮盖策略复制并遮盖请求体中的当事人信息
+func (m *MaskConfig) Body(body *CaseBody) *CaseBody {
+  b := *body
+  b.ApplicantList = make([]*ApplicantBody, len(body.ApplicantList))
+  for i, app := range body.ApplicantList {
+    a := *app
+    a.Name = m.Name(a.Name)
+    a.Tel = m.Phone(a.Tel)
+    a.IDCardNo = m.IDCard(a.IDCardNo)
+    a.Address = m.Address(a.Address)
+    b.ApplicantList[i] = &a
+  }
+
+  b.RespondentList = make([]*RespondentBody, len(body.RespondentList))
+  for i, res := range body.RespondentList {
+    r := *res
+    r.Name = m.Name(r.Name)
+    r.Tel = m.Phone(r.Tel)
+    r.StaticPhone = m.Phone(r.StaticPhone)
+    r.IDCardNo = m.IDCard(r.IDCardNo)
+    r.Address = m.Address(r.Address)
+    b.RespondentList[i] = &r
+  }
+
+  return &b
+}
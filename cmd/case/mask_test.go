@@ -0,0 +1,47 @@
+package main
+
+import (
+  "testing"
+)
+
+func TestMask(t *testing.T) {
+  tests := []struct {
+    name string
+    fn   func(string) string
+    in   string
+    want string
+  }{
+    { "name", MaskName, "张三丰", "张*丰" },
+    { "name two", MaskName, "李四", "李*" },
+    { "name one", MaskName, "王", "*" },
+    { "mobile", MaskPhone, "13800001234", "138****1234" },
+    { "landline", MaskPhone, "0571-1234567", "0571-***4567" },
+    { "short phone", MaskPhone, "1234567", "*****67" },
+    { "id card", MaskIDCard, "110101199001011234", "110101********1234" },
+    { "short id", MaskIDCard, "12345", "*****" },
+    { "address", MaskAddress, "浙江省杭州市西湖区", "浙江省杭州市****" },
+    { "short address", MaskAddress, "杭州市", "杭州市" },
+  }
+
+  for _, tt := range tests {
+    if got := tt.fn(tt.in); got != tt.want {
+      t.Errorf("%s(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+    }
+  }
+}
+
+func TestMaskPolicy(t *testing.T) {
+  var none *MaskConfig
+  if got := none.Name("张三丰"); got != "张*丰" {
+    t.Errorf("nil policy Name = %q, want masked", got)
+  }
+
+  if got := NoMask().Phone("13800001234"); got != "13800001234" {
+    t.Errorf("NoMask Phone = %q, want unmasked", got)
+  }
+
+  m := &MaskConfig{ Names: true }
+  if got := m.IDCard("110101199001011234"); got != "110101199001011234" {
+    t.Errorf("IDCard = %q, want unmasked", got)
+  }
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+  "os"
+  "fmt"
+  "sort"
+  "reflect"
+  "strings"
+  "path/filepath"
+  "encoding/json"
+)
+
+const (
+  // 当前配置文件版本
+  CONFIG_VERSION = 1
+)
+
+// 配置升级函数（只修改内存中的配置），下标为升级前的版本
+var migrations = []func(doc map[string]interface{}) error{
+  migrateV0,
+}
+
+// 已弃用的配置项及说明（消息编号）
+var deprecatedKeys = map[string]string{
+  "case.startTime": "deprecated.randomDate",
+  "case.endTime":   "deprecated.randomDate",
+}
+
+// v0（无版本号）-> v1：补全缺失的配置项；配置文件中的cookie保留在内存中，
+// 由case init写入新配置时移至cookie文件（见MoveCookie）
+func migrateV0(doc map[string]interface{}) error {
+  defaults, err := json.Marshal(InitConf())
+  if err != nil {
+    return err
+  }
+
+  var def map[string]interface{}
+  if err := json.Unmarshal(defaults, &def); err != nil {
+    return err
+  }
+
+  fillDefaults(doc, def)
+
+  // 移除为空的已弃用配置项
+  if ca, ok := doc["case"].(map[string]interface{}); ok {
+    for _, key := range []string{ "startTime", "endTime" } {
+      if v, _ := ca[key].(string); v == "" {
+        delete(ca, key)
+      }
+    }
+  }
+
+  return nil
+}
+
+// v1起cookie不再保存在配置文件中：将cookie写入配置文件旁的新文件，
+// 已有cookie.txt时改用cookie-1.txt等，不覆盖已有文件
+func MoveCookie(req *RequestConfig, confPath string) error {
+  if req == nil || req.Cookie == "" || req.CookieFile != "" {
+    return nil
+  }
+
+  dir := filepath.Dir(confPath)
+  for i := 0; ; i++ {
+    name := "cookie.txt"
+    if i > 0 {
+      name = fmt.Sprintf("cookie-%d.txt", i)
+    }
+
+    path := filepath.Join(dir, name)
+    f, err := os.OpenFile(path, os.O_WRONLY | os.O_CREATE | os.O_EXCL, 0600)
+    if os.IsExist(err) {
+      continue
+    }
+    if err != nil {
+      return NewError("err.cookieWrite", err)
+    }
+
+    _, err = f.WriteString(req.Cookie)
+    if cerr := f.Close(); err == nil {
+      err = cerr
+    }
+    if err != nil {
+      os.Remove(path)
+      return NewError("err.cookieWrite", err)
+    }
+
+    req.CookieFile = path
+    Logger.Info(T("log.cookieMoved"), "cookieFile", path)
+    return nil
+  }
+}
+
+// 用默认配置补全缺失的配置项（不进入自定义映射与配置方案）
+func fillDefaults(doc map[string]interface{}, def map[string]interface{}) {
+  for key, dv := range def {
+    if key == "mapper" || key == "profiles" {
+      if _, ok := doc[key]; !ok {
+        doc[key] = dv
+      }
+      continue
+    }
+
+    v, ok := doc[key]
+    if !ok || v == nil {
+      doc[key] = dv
+      continue
+    }
+
+    dm, dok := dv.(map[string]interface{})
+    m, ok := v.(map[string]interface{})
+    if dok && ok {
+      fillDefaults(m, dm)
+    }
+  }
+}
+
+// 在内存中升级旧版本配置（不改写文件），返回升级前的版本
+func MigrateConf(doc map[string]interface{}) (int, error) {
+  version := 0
+  if v, ok := doc["version"].(float64); ok {
+    version = int(v)
+  }
+
+  if version > CONFIG_VERSION {
+    return version, NewError("err.version", version, CONFIG_VERSION)
+  }
+
+  for v := version; v < CONFIG_VERSION; v++ {
+    if err := migrations[v](doc); err != nil {
+      return version, NewError("err.migrate", v, err)
+    }
+  }
+
+  doc["version"] = CONFIG_VERSION
+  return version, nil
+}
+
+// 检查未知与已弃用的配置项
+func CheckKeys(doc map[string]interface{}) Issues {
+  var issues Issues
+  checkKeys(doc, reflect.TypeOf(GlobalConfig{}), "", &issues)
+  return issues
+}
+
+func checkKeys(doc map[string]interface{}, t reflect.Type, prefix string, issues *Issues) {
+  fields := map[string]reflect.Type{}
+  for i := 0; i < t.NumField(); i++ {
+    name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+    if name != "" && name != "-" {
+      fields[name] = t.Field(i).Type
+    }
+  }
+
+  keys := make([]string, 0, len(doc))
+  for key := range doc {
+    keys = append(keys, key)
+  }
+  sort.Strings(keys)
+
+  for _, key := range keys {
+    path := key
+    if prefix != "" {
+      path = prefix + "." + key
+    }
+
+    if key == "$schema" && prefix == "" {
+      continue
+    }
+
+    ft, ok := fields[key]
+    if !ok {
+      issues.Warn(path, "CONF_KEY_UNKNOWN")
+      continue
+    }
+
+    if reason, ok := deprecatedKeys[strings.TrimPrefix(path, profilePrefix(path))]; ok {
+      issues.Warn(path, "CONF_KEY_DEPRECATED", T(reason))
+    }
+
+    if key == "sources" && prefix == "data" {
+      items, _ := doc[key].([]interface{})
+      for i, item := range items {
+        if m, ok := item.(map[string]interface{}); ok {
+          checkKeys(m, reflect.TypeOf(DataConfig{}), fmt.Sprintf("%s[%d]", path, i), issues)
+        }
+      }
+      continue
+    }
+
+    child, ok := doc[key].(map[string]interface{})
+    if !ok {
+      continue
+    }
+
+    switch {
+    case ft.Kind() == reflect.Ptr && ft.Elem().Kind() == reflect.Struct:
+      checkKeys(child, ft.Elem(), path, issues)
+    case key == "profiles" && prefix == "":
+      for name, p := range child {
+        if pm, ok := p.(map[string]interface{}); ok {
+          checkKeys(pm, reflect.TypeOf(GlobalConfig{}), "profiles." + name, issues)
+        }
+      }
+    }
+  }
+}
+
+// 配置方案中的字段路径前缀，如 profiles.labor.
+func profilePrefix(path string) string {
+  if !strings.HasPrefix(path, "profiles.") {
+    return ""
+  }
+
+  parts := strings.SplitN(path, ".", 3)
+  if len(parts) < 3 {
+    return path
+  }
+
+  return parts[0] + "." + parts[1] + "."
+}
+
+// 读取配置文件的原始内容（JSON形式），检查配置项并按需在内存中升级，返回升级前的版本
+func loadConfDoc(path string, data []byte) ([]byte, Issues, int, error) {
+  var doc map[string]interface{}
+  if err := json.Unmarshal(data, &doc); err != nil {
+    return nil, nil, 0, err
+  }
+
+  if doc == nil {
+    doc = map[string]interface{}{}
+  }
+
+  version, err := MigrateConf(doc)
+  if err != nil {
+    return nil, nil, version, err
+  }
+
+  issues := CheckKeys(doc)
+
+  if version == CONFIG_VERSION {
+    return data, issues, version, nil
+  }
+
+  // 只读的命令不改写配置文件（YAML、TOML中的注释会丢失），提示用case init升级
+  issues.Warn("version", "CONF_VERSION_OLD", version, path, path)
+
+  data, err = json.Marshal(doc)
+  return data, issues, version, err
+}
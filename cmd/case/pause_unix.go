@@ -0,0 +1,11 @@
+//go:build !windows
+
+package main
+
+import (
+  "os"
+  "syscall"
+)
+
+// 暂停与继续信号
+var pauseSignals = []os.Signal{ syscall.SIGUSR1 }
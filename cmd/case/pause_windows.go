@@ -0,0 +1,10 @@
+//go:build windows
+
+package main
+
+import (
+  "os"
+)
+
+// Windows没有SIGUSR1，只能在终端中按回车暂停与继续
+var pauseSignals = []os.Signal{}
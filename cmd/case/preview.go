@@ -0,0 +1,214 @@
+package main
+
+import (
+  "io"
+  "os"
+  "fmt"
+  "encoding/json"
+  "html/template"
+  "text/tabwriter"
+
+  "github.com/urfave/cli/v2"
+  "github.com/xuri/excelize/v2"
+
+  "github.com/NataRich/auto-case/court"
+)
+
+// 单行预览
+type PreviewRow struct {
+  // 数据源（多个数据源时）
+  Source      string          `json:"source,omitempty"`
+
+  // excel行号
+  Row         int             `json:"row"`
+
+  // 该行使用的配置方案（基础配置为空）
+  Profile     string          `json:"profile,omitempty"`
+
+  // 检查问题
+  Issues      Issues          `json:"issues"`
+
+  // 请求体（已遮盖证件号码，检查失败时为空）
+  Body        *CaseBody       `json:"body"`
+}
+
+// 预览结果
+type Preview struct {
+  Endpoint    string          `json:"endpoint"`
+  Cookie      string          `json:"cookie"`
+  Rows        []*PreviewRow   `json:"rows"`
+}
+
+// 生成指定行的请求体，不发送请求
+func BuildPreview(conf *GlobalConfig, data *DataConfig) (*Preview, error) {
+  preview := &Preview{
+    Endpoint: NewClient(conf.Request, conf.Debug).Endpoint,
+    Cookie:   Masking.Cookie(conf.Request.Cookie),
+    Rows:     []*PreviewRow{},
+  }
+
+  cases, err := NewCaseSet(conf)
+  if err != nil {
+    return nil, err
+  }
+
+  sources, err := data.Expand()
+  if err != nil {
+    return nil, err
+  }
+
+  for _, src := range sources {
+    label := ""
+    if len(sources) > 1 {
+      label = src.Label()
+    }
+
+    err := EachRow(src, func(line int, row []string, rowErr error) error {
+      if rowErr == ErrBlankRow {
+        return nil
+      }
+
+      if rowErr != nil {
+        r := &PreviewRow{ Source: label, Row: line }
+        r.Issues.Error("", "ROW_UNREADABLE", rowErr).Row = line
+        preview.Rows = append(preview.Rows, r)
+        return nil
+      }
+
+      ca, issues := PrepareRow(cases, src, row, line)
+      r := &PreviewRow{ Source: label, Row: line, Issues: issues }
+      if src.ProfileCol != "" {
+        col, _ := excelize.ColumnNameToNumber(src.ProfileCol)
+        r.Profile = cellAt(row, col)
+      }
+
+      if ca != nil {
+        InsertRandomDates(ca)
+        r.Body = Masking.Body(court.BuildCaseBody(ca))
+      }
+
+      preview.Rows = append(preview.Rows, r)
+      return nil
+    })
+
+    if err != nil {
+      return preview, err
+    }
+  }
+
+  return preview, nil
+}
+
+// 以JSON形式输出
+func (p *Preview) PrintJSON(w io.Writer) error {
+  data, err := json.MarshalIndent(p, "", "  ")
+  if err != nil {
+    return err
+  }
+
+  _, err = fmt.Fprintf(w, "%s\n", data)
+  return err
+}
+
+// 以紧凑表格形式输出
+func (p *Preview) PrintTable(w io.Writer) error {
+  fmt.Fprintf(w, "%s%s%s\nCookie%s%s\n\n", T("preview.endpoint"), T("issue.sep"), p.Endpoint,
+              T("issue.sep"), p.Cookie)
+
+  tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+  fmt.Fprintln(tw, T("preview.header"))
+  for _, r := range p.Rows {
+    errs, warns := r.Issues.Count()
+    if r.Body == nil {
+      fmt.Fprintf(tw, "%d\t%s\t\t\t\t\t\t\t\t\t\t\t\t%s\n", r.Row, r.Profile, T("preview.issues", errs, warns))
+      continue
+    }
+
+    app := r.Body.ApplicantList[0]
+    res := r.Body.RespondentList[0]
+    fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+                r.Row, r.Profile, app.Name, app.Tel, app.IDCardNo,
+                res.Name, res.Tel, res.StaticPhone, res.IDCardNo,
+                r.Body.CauseCode, r.Body.MediatorId,
+                r.Body.StartTime, r.Body.EndTime, T("preview.issues", errs, warns))
+  }
+
+  return tw.Flush()
+}
+
+var previewTemplate = template.Must(template.New("preview").Funcs(template.FuncMap{
+  "t":    T,
+  "lang": func() Lang { return CurrentLang },
+}).Parse(`<!DOCTYPE html>
+<html lang="{{lang}}">
+<head>
+<meta charset="utf-8">
+<title>{{t "preview.title"}}</title>
+<style>
+  body { font-family: sans-serif; margin: 2em; }
+  table { border-collapse: collapse; margin-bottom: 1em; }
+  th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+  th { background: #f4f4f4; }
+  .error { color: #b00; }
+  .warning { color: #a60; }
+</style>
+</head>
+<body>
+<h1>{{t "preview.title"}}</h1>
+<p>{{t "preview.endpoint"}}{{t "issue.sep"}}{{.Endpoint}}<br>Cookie{{t "issue.sep"}}{{.Cookie}}<br>{{t "preview.rows" (len .Rows)}}</p>
+{{range .Rows}}
+<h2>{{t "preview.row" .Row}}{{if .Profile}}{{t "issue.cell" .Profile}}{{end}}</h2>
+{{if .Issues}}<ul>{{range .Issues}}<li class="{{.Severity}}">{{.}}</li>{{end}}</ul>{{end}}
+{{with .Body}}<table>
+  <tr><th>{{t "preview.cause"}}</th><td>{{.CauseCode}}</td><th>{{t "preview.disputeType"}}</th><td>{{.DisputeType}}</td></tr>
+  <tr><th>{{t "preview.mediator"}}</th><td>{{.MediatorId}}</td><th>{{t "preview.dates"}}</th><td>{{.StartTime}} {{t "preview.to"}} {{.EndTime}}</td></tr>
+  {{range .ApplicantList}}
+  <tr><th>{{t "preview.applicant"}}</th><td>{{.Name}}</td><th>{{t "preview.mobile"}}</th><td>{{.Tel}}</td></tr>
+  <tr><th>{{t "preview.id"}}</th><td>{{.IDCardNo}}</td><th>{{t "preview.address"}}</th><td>{{.Address}}</td></tr>
+  {{end}}
+  {{range .RespondentList}}
+  <tr><th>{{t "preview.respondent"}}</th><td>{{.Name}}</td><th>{{t "preview.phones"}}</th><td>{{.Tel}} {{.StaticPhone}}</td></tr>
+  <tr><th>{{t "preview.id"}}</th><td>{{.IDCardNo}}</td><th>{{t "preview.address"}}</th><td>{{.Address}}</td></tr>
+  {{end}}
+  <tr><th>{{t "preview.dispute"}}</th><td colspan="3">{{.Dispute}}</td></tr>
+  <tr><th>{{t "preview.agreement"}}</th><td colspan="3">{{.Agreement}}</td></tr>
+</table>{{end}}
+{{end}}
+</body>
+</html>
+`))
+
+// 以HTML页面形式输出
+func (p *Preview) PrintHTML(w io.Writer) error {
+  return previewTemplate.Execute(w, p)
+}
+
+// 预览指定行的请求体
+func previewCase(ctx *cli.Context) error {
+  if err := loadConf(ctx); err != nil {
+    return err
+  }
+
+  issues := PreCheck(Conf)
+  if issues.HasErrorIn("case") || issues.HasErrorIn("data") {
+    issues.Log(Logger)
+    return NewError("err.precheck")
+  }
+
+  // --rows已在加载配置时应用到数据源
+  preview, err := BuildPreview(Conf, Conf.Data)
+  if err != nil {
+    return err
+  }
+
+  switch ctx.String("format") {
+  case "json":
+    return preview.PrintJSON(os.Stdout)
+  case "table":
+    return preview.PrintTable(os.Stdout)
+  case "html":
+    return preview.PrintHTML(os.Stdout)
+  }
+
+  return NewError("err.format", ctx.String("format"))
+}
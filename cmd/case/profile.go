@@ -0,0 +1,170 @@
+package main
+
+import (
+  "os"
+  "fmt"
+  "sort"
+  "strings"
+  "encoding/json"
+  "text/tabwriter"
+
+  "github.com/urfave/cli/v2"
+  "github.com/xuri/excelize/v2"
+)
+
+// 配置方案参数
+var profileFlag = &cli.StringFlag{
+  Name: "profile",
+  Aliases: []string{ "p" },
+  Usage: T("flag.profile"),
+}
+
+// 配置方案名称（已排序）
+func (conf *GlobalConfig) ProfileNames() []string {
+  names := make([]string, 0, len(conf.Profiles))
+  for name := range conf.Profiles {
+    names = append(names, name)
+  }
+
+  sort.Strings(names)
+  return names
+}
+
+// 以基础配置为底，叠加指定配置方案，返回新的配置
+func ApplyProfile(conf *GlobalConfig, name string) (*GlobalConfig, error) {
+  raw, ok := conf.Profiles[name]
+  if !ok {
+    return nil, NewError("err.profileUnknown", name, strings.Join(conf.ProfileNames(), ", "))
+  }
+
+  base, err := json.Marshal(conf)
+  if err != nil {
+    return nil, err
+  }
+
+  res := &GlobalConfig{}
+  if err := json.Unmarshal(base, res); err != nil {
+    return nil, err
+  }
+
+  // 配置方案中出现的字段覆盖基础配置，未出现的字段继承基础配置
+  if err := json.Unmarshal(raw, res); err != nil {
+    return nil, NewError("err.profileFormat", name, err)
+  }
+
+  res.Profiles = conf.Profiles
+  res.warnings = conf.warnings
+  return res, nil
+}
+
+// 配置方案覆盖的配置项，如 case.causeCode
+func profileKeys(raw json.RawMessage) []string {
+  var doc map[string]interface{}
+  if err := json.Unmarshal(raw, &doc); err != nil {
+    return nil
+  }
+
+  var keys []string
+  var walk func(prefix string, v interface{})
+  walk = func(prefix string, v interface{}) {
+    m, ok := v.(map[string]interface{})
+    if !ok || prefix == "data.mapper" {
+      keys = append(keys, prefix)
+      return
+    }
+
+    for k, child := range m {
+      key := k
+      if prefix != "" {
+        key = prefix + "." + k
+      }
+      walk(key, child)
+    }
+  }
+
+  walk("", doc)
+  sort.Strings(keys)
+  return keys
+}
+
+// 列出配置文件中的配置方案
+func listProfiles(ctx *cli.Context) error {
+  if _, err := LoadConf(ctx.String("config")); err != nil {
+    return err
+  }
+
+  if len(Conf.Profiles) == 0 {
+    fmt.Println(T("profiles.none"))
+    return nil
+  }
+
+  tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+  fmt.Fprintln(tw, T("profiles.header"))
+  for _, name := range Conf.ProfileNames() {
+    conf, err := ApplyProfile(Conf, name)
+    if err != nil {
+      return err
+    }
+
+    var cause, dispute, mediator string
+    if conf.Case != nil {
+      cause, dispute, mediator = conf.Case.CauseCode, conf.Case.DisputeType, conf.Case.DefaultMediatorId
+    }
+
+    fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", name, cause, dispute, mediator,
+                strings.Join(profileKeys(Conf.Profiles[name]), ", "))
+  }
+
+  return tw.Flush()
+}
+
+// 按行选择的案件配置
+type CaseSet struct {
+  // 基础案件配置，用于未指定配置方案的行
+  Base        *CaseConfig
+
+  // 各配置方案的案件配置
+  Profiles    map[string]*CaseConfig
+}
+
+// 预先解析所有配置方案的案件配置
+func NewCaseSet(conf *GlobalConfig) (*CaseSet, error) {
+  cs := &CaseSet{ Base: conf.Case, Profiles: map[string]*CaseConfig{} }
+  if conf.Data == nil || !conf.Data.UsesProfiles() {
+    return cs, nil
+  }
+
+  for _, name := range conf.ProfileNames() {
+    pc, err := ApplyProfile(conf, name)
+    if err != nil {
+      return nil, err
+    }
+    cs.Profiles[name] = pc.Case
+  }
+
+  return cs, nil
+}
+
+// 按配置方案列的单元格选择该行的案件配置，返回方案名称（基础配置为空）
+func (cs *CaseSet) Pick(data *DataConfig, row []string) (*CaseConfig, string, *Issue) {
+  if data.ProfileCol == "" {
+    return cs.Base, "", nil
+  }
+
+  col, err := excelize.ColumnNameToNumber(data.ProfileCol)
+  if err != nil {
+    return nil, "", NewIssue(SEVERITY_ERROR, "data.profileCol", "DATA_PROFILE_COL_INVALID", data.ProfileCol)
+  }
+
+  name := cellAt(row, col)
+  if name == "" {
+    return cs.Base, "", nil
+  }
+
+  ca, ok := cs.Profiles[name]
+  if !ok {
+    return nil, name, NewIssue(SEVERITY_ERROR, "data.profileCol", "PROFILE_UNKNOWN", name)
+  }
+
+  return ca, name, nil
+}
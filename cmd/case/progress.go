@@ -0,0 +1,163 @@
+package main
+
+import (
+  "io"
+  "os"
+  "fmt"
+  "sync"
+  "time"
+  "strings"
+
+  "golang.org/x/term"
+)
+
+const (
+  // 进度条宽度（字符）
+  PROGRESS_WIDTH = 24
+
+  // 非终端输出时打印进度的间隔
+  PROGRESS_INTERVAL = 30 * time.Second
+)
+
+// 批量新建的进度显示：终端中原地刷新进度条，否则定期输出一行
+type Progress struct {
+  mu        sync.Mutex
+  w         io.Writer
+  tty       bool
+  summary   *Summary
+
+  // 预计处理行数
+  total     int
+
+  // 当前行号
+  line      int
+
+  // 上次输出时间（非终端）
+  printed   time.Time
+}
+
+// 新建进度显示，按summary统计已处理行数，total为预计处理行数
+func NewProgress(f *os.File, summary *Summary, total int) *Progress {
+  return &Progress{
+    w:        f,
+    tty:      term.IsTerminal(int(f.Fd())),
+    summary:  summary,
+    total:    total,
+    printed:  time.Now(),
+  }
+}
+
+// 开始处理某行
+func (p *Progress) Start(line int) {
+  p.mu.Lock()
+  defer p.mu.Unlock()
+
+  p.line = line
+  if p.tty {
+    p.draw()
+  }
+}
+
+// 某行处理完成后刷新
+func (p *Progress) Update() {
+  p.mu.Lock()
+  defer p.mu.Unlock()
+
+  if p.tty {
+    p.draw()
+  } else if time.Since(p.printed) >= PROGRESS_INTERVAL {
+    fmt.Fprintln(p.w, p.String())
+    p.printed = time.Now()
+  }
+}
+
+// 结束进度显示，输出最终进度（数据不足预计行数时以实际行数为准）
+func (p *Progress) Finish() {
+  p.mu.Lock()
+  defer p.mu.Unlock()
+
+  if !p.summary.Interrupted {
+    p.total = p.summary.Counts().Read
+  }
+  if p.tty {
+    p.draw()
+  }
+  fmt.Fprintln(p.w, p.tail())
+}
+
+// 终端中清除进度行，以便输出日志
+func (p *Progress) clear() {
+  p.mu.Lock()
+  defer p.mu.Unlock()
+
+  if p.tty {
+    fmt.Fprint(p.w, "\r\033[K")
+  }
+}
+
+// 终端中重新绘制进度行
+func (p *Progress) redraw() {
+  p.mu.Lock()
+  defer p.mu.Unlock()
+
+  if p.tty {
+    p.draw()
+  }
+}
+
+func (p *Progress) draw() {
+  fmt.Fprint(p.w, "\r\033[K" + p.String())
+}
+
+func (p *Progress) tail() string {
+  if p.tty {
+    return ""
+  }
+  return p.String()
+}
+
+// 进度行，如 行 17 [=====>    ] 12/100 12% 成功 10 失败 1 跳过 1 剩余约 3m20s
+func (p *Progress) String() string {
+  s := p.summary.Counts()
+  done := s.Read
+  total := p.total
+  if total < done {
+    total = done
+  }
+
+  pct := 100
+  if total > 0 {
+    pct = done * 100 / total
+  }
+
+  return T("progress.line", p.line, progressBar(done, total), done, total, pct,
+           s.Succeeded, s.Failed, s.Skipped + s.Invalid, p.eta(done, total))
+}
+
+// 按已处理行的平均用时估算剩余时间
+func (p *Progress) eta(done int, total int) string {
+  if done == 0 {
+    return "--"
+  }
+
+  if done >= total {
+    return "0s"
+  }
+
+  per := time.Since(p.summary.StartedAt) / time.Duration(done)
+  return (per * time.Duration(total - done)).Round(time.Second).String()
+}
+
+func progressBar(done int, total int) string {
+  n := PROGRESS_WIDTH
+  if total > 0 {
+    n = done * PROGRESS_WIDTH / total
+  }
+
+  bar := strings.Repeat("=", n)
+  if n < PROGRESS_WIDTH {
+    bar += ">" + strings.Repeat(" ", PROGRESS_WIDTH - n - 1)
+  }
+
+  return "[" + bar + "]"
+}
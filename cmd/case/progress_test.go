@@ -0,0 +1,68 @@
+package main
+
+import (
+  "os"
+  "time"
+  "strings"
+  "testing"
+)
+
+func TestProgressBar(t *testing.T) {
+  tests := []struct {
+    done  int
+    total int
+    want  string
+  }{
+    { 0, 10, "[>" + strings.Repeat(" ", PROGRESS_WIDTH - 1) + "]" },
+    { 5, 10, "[" + strings.Repeat("=", PROGRESS_WIDTH / 2) + ">" + strings.Repeat(" ", PROGRESS_WIDTH / 2 - 1) + "]" },
+    { 10, 10, "[" + strings.Repeat("=", PROGRESS_WIDTH) + "]" },
+  }
+
+  for _, tt := range tests {
+    if got := progressBar(tt.done, tt.total); got != tt.want {
+      t.Errorf("progressBar(%d, %d) = %q, want %q", tt.done, tt.total, got, tt.want)
+    }
+  }
+}
+
+func TestProgressETA(t *testing.T) {
+  s := NewSummary("test")
+  s.StartedAt = time.Now().Add(-time.Minute)
+  p := NewProgress(os.Stdout, s, 10)
+
+  if got := p.eta(0, 10); got != "--" {
+    t.Errorf("eta before any row = %q", got)
+  }
+
+  // 每行约12秒，剩余5行约1分钟
+  if got := p.eta(5, 10); got != "1m0s" {
+    t.Errorf("eta = %q, want 1m0s", got)
+  }
+
+  if got := p.eta(10, 10); got != "0s" {
+    t.Errorf("eta when done = %q", got)
+  }
+}
+
+// 日志输出时重绘进度行与提交过程同时进行（配合 go test -race）
+func TestProgressConcurrent(t *testing.T) {
+  s := NewSummary("test")
+  p := NewProgress(os.Stdout, s, 100)
+
+  done := make(chan struct{})
+  go func() {
+    defer close(done)
+    for i := 0; i < 100; i++ {
+      _ = p.String()
+    }
+  }()
+
+  for i := 0; i < 100; i++ {
+    s.Submit(i + 2, "张三", "李四", nil)
+  }
+  <-done
+
+  if c := s.Counts(); c.Read != 100 || c.Succeeded != 100 {
+    t.Errorf("Counts = %+v", c)
+  }
+}
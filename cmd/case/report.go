@@ -0,0 +1,304 @@
+package main
+
+import (
+  "io"
+  "os"
+  "fmt"
+  "sort"
+  "sync"
+  "time"
+  "strconv"
+  "strings"
+  "io/ioutil"
+  "encoding/csv"
+  "encoding/json"
+  "path/filepath"
+
+  "github.com/xuri/excelize/v2"
+)
+
+const (
+  // 运行报告目录
+  REPORT_DIR = "reports"
+)
+
+// 单行处理结果
+const (
+  ROW_SKIPPED   = "skipped"
+  ROW_INVALID   = "invalid"
+  ROW_SUCCEEDED = "succeeded"
+  ROW_FAILED    = "failed"
+)
+
+// 单行处理记录
+type RowResult struct {
+  // 数据源（多个数据源时）
+  Source      string          `json:"source,omitempty"`
+
+  Row         int             `json:"row"`
+  Applicant   string          `json:"applicant,omitempty"`
+  Respondent  string          `json:"respondent,omitempty"`
+  Status      string          `json:"status"`
+  Reason      string          `json:"reason,omitempty"`
+}
+
+// 批量新建的运行汇总
+type Summary struct {
+  // 数据源
+  Source      string          `json:"source"`
+
+  StartedAt   time.Time       `json:"startedAt"`
+  FinishedAt  time.Time       `json:"finishedAt"`
+
+  // 读取行数
+  Read        int             `json:"read"`
+
+  // 跳过行数及原因
+  Skipped     int             `json:"skipped"`
+  SkipReasons map[string]int  `json:"skipReasons"`
+
+  // 检查未通过行数
+  Invalid     int             `json:"invalid"`
+
+  // 已提交、成功、重试后仍失败的行数
+  Submitted   int             `json:"submitted"`
+  Succeeded   int             `json:"succeeded"`
+  Failed      int             `json:"failed"`
+
+  // 用时（秒）与吞吐量（每分钟提交行数）
+  Duration    float64         `json:"durationSeconds"`
+  Throughput  float64         `json:"perMinute"`
+
+  // 是否被中断（Ctrl-C），此时未处理剩余各行
+  Interrupted bool            `json:"interrupted,omitempty"`
+
+  // 进程退出码
+  ExitCode    int             `json:"exitCode"`
+
+  Rows        []*RowResult    `json:"rows"`
+
+  // 进度显示在日志输出时读取计数，记录与读取时加锁
+  mu          sync.Mutex
+
+  // 当前数据源
+  current     string
+}
+
+// 进度显示用的计数快照
+type SummaryCounts struct {
+  Read        int
+  Skipped     int
+  Invalid     int
+  Succeeded   int
+  Failed      int
+}
+
+// 当前计数（加锁读取，可在其他goroutine中调用）
+func (s *Summary) Counts() SummaryCounts {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  return SummaryCounts{ Read: s.Read, Skipped: s.Skipped, Invalid: s.Invalid, Succeeded: s.Succeeded, Failed: s.Failed }
+}
+
+func NewSummary(source string) *Summary {
+  return &Summary{
+    Source:      source,
+    StartedAt:   time.Now(),
+    SkipReasons: map[string]int{},
+    Rows:        []*RowResult{},
+  }
+}
+
+// 之后记录的行属于该数据源
+func (s *Summary) At(source string) {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  s.current = source
+}
+
+// 记录跳过的行
+func (s *Summary) Skip(line int, reason string) {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  s.Read++
+  s.Skipped++
+  s.SkipReasons[reason]++
+  s.Rows = append(s.Rows, &RowResult{ Source: s.current, Row: line, Status: ROW_SKIPPED, Reason: reason })
+}
+
+// 记录检查未通过的行
+func (s *Summary) Reject(line int, app string, res string, reason string) {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  s.Read++
+  s.Invalid++
+  s.Rows = append(s.Rows, &RowResult{
+    Source: s.current, Row: line, Applicant: Masking.Name(app), Respondent: Masking.Name(res),
+    Status: ROW_INVALID, Reason: reason,
+  })
+}
+
+// 记录已提交的行，err为空表示成功
+func (s *Summary) Submit(line int, app string, res string, err error) {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  s.Read++
+  s.Submitted++
+
+  r := &RowResult{
+    Source: s.current, Row: line, Applicant: Masking.Name(app), Respondent: Masking.Name(res), Status: ROW_SUCCEEDED,
+  }
+  if err != nil {
+    s.Failed++
+    r.Status = ROW_FAILED
+    r.Reason = err.Error()
+  } else {
+    s.Succeeded++
+  }
+  s.Rows = append(s.Rows, r)
+}
+
+// 结束计时
+func (s *Summary) Finish() {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  s.FinishedAt = time.Now()
+  s.Duration = s.FinishedAt.Sub(s.StartedAt).Seconds()
+  if s.Duration > 0 {
+    s.Throughput = float64(s.Submitted) / (s.Duration / 60)
+  }
+}
+
+// 汇总指标（名称、数值），用于控制台与CSV
+func (s *Summary) metrics() [][2]string {
+  m := [][2]string{
+    { T("summary.source"), s.Source },
+    { T("summary.startedAt"), s.StartedAt.Format("2006-01-02 15:04:05") },
+    { T("summary.finishedAt"), s.FinishedAt.Format("2006-01-02 15:04:05") },
+    { T("summary.read"), strconv.Itoa(s.Read) },
+    { T("summary.skipped"), strconv.Itoa(s.Skipped) },
+  }
+
+  reasons := make([]string, 0, len(s.SkipReasons))
+  for reason := range s.SkipReasons {
+    reasons = append(reasons, reason)
+  }
+  sort.Strings(reasons)
+  for _, reason := range reasons {
+    m = append(m, [2]string{ T("summary.skipReason", reason), strconv.Itoa(s.SkipReasons[reason]) })
+  }
+
+  if s.Interrupted {
+    m = append(m, [2]string{ T("summary.interrupted"), T("summary.yes") })
+  }
+
+  return append(m,
+    [2]string{ T("summary.invalid"), strconv.Itoa(s.Invalid) },
+    [2]string{ T("summary.submitted"), strconv.Itoa(s.Submitted) },
+    [2]string{ T("summary.succeeded"), strconv.Itoa(s.Succeeded) },
+    [2]string{ T("summary.failed"), strconv.Itoa(s.Failed) },
+    [2]string{ T("summary.duration"), time.Duration(s.Duration * float64(time.Second)).Round(time.Second).String() },
+    [2]string{ T("summary.throughput"), strconv.FormatFloat(s.Throughput, 'f', 2, 64) },
+    [2]string{ T("summary.exitCode"), T("summary.exitCodeValue", s.ExitCode, ExitMessage(s.ExitCode)) },
+  )
+}
+
+// 打印到控制台
+func (s *Summary) Print(w io.Writer) {
+  fmt.Fprintln(w, T("summary.title"))
+  for _, kv := range s.metrics() {
+    fmt.Fprintf(w, "%s%s%s\n", kv[0], T("summary.sep"), kv[1])
+  }
+  fmt.Fprintln(w, T("summary.line"))
+}
+
+// 以JSON与CSV写入报告目录，返回写入的文件
+func (s *Summary) Save(dir string) ([]string, error) {
+  return s.SaveAs(dir, "run-" + s.StartedAt.Format("20060102-150405"))
+}
+
+// 以JSON与CSV写入报告目录，文件名为name加扩展名，返回写入的文件
+func (s *Summary) SaveAs(dir string, name string) ([]string, error) {
+  if err := os.MkdirAll(dir, 0755); err != nil {
+    return nil, err
+  }
+
+  base := filepath.Join(dir, name)
+
+  data, err := json.MarshalIndent(s, "", "  ")
+  if err != nil {
+    return nil, err
+  }
+
+  if err := ioutil.WriteFile(base + ".json", data, 0644); err != nil {
+    return nil, err
+  }
+
+  f, err := os.Create(base + ".csv")
+  if err != nil {
+    return nil, err
+  }
+  defer f.Close()
+
+  // UTF-8 BOM，便于Excel正确显示中文
+  f.WriteString("\xEF\xBB\xBF")
+
+  w := csv.NewWriter(f)
+  w.Write([]string{ T("summary.metric"), T("summary.value") })
+  for _, kv := range s.metrics() {
+    w.Write([]string{ kv[0], kv[1] })
+  }
+
+  w.Write([]string{})
+  w.Write(strings.Split(T("summary.rowHeader"), ","))
+  for _, r := range s.Rows {
+    w.Write(r.record())
+  }
+
+  w.Flush()
+  if err := w.Error(); err != nil {
+    return nil, err
+  }
+
+  return []string{ base + ".json", base + ".csv" }, nil
+}
+
+// 以工作簿写入结果：汇总指标与各行明细各占一个工作表
+func (s *Summary) SaveWorkbook(path string) error {
+  f := excelize.NewFile()
+  defer f.Close()
+
+  summary, rows := T("summary.sheet"), T("summary.rowsSheet")
+  if err := f.SetSheetName(f.GetSheetName(0), summary); err != nil {
+    return err
+  }
+
+  if _, err := f.NewSheet(rows); err != nil {
+    return err
+  }
+
+  f.SetSheetRow(summary, "A1", &[]string{ T("summary.metric"), T("summary.value") })
+  for i, kv := range s.metrics() {
+    f.SetSheetRow(summary, fmt.Sprintf("A%d", i + 2), &[]string{ kv[0], kv[1] })
+  }
+
+  header := strings.Split(T("summary.rowHeader"), ",")
+  f.SetSheetRow(rows, "A1", &header)
+  for i, r := range s.Rows {
+    record := r.record()
+    f.SetSheetRow(rows, fmt.Sprintf("A%d", i + 2), &record)
+  }
+
+  return f.SaveAs(path)
+}
+
+// 明细中的一行，列同summary.rowHeader
+func (r *RowResult) record() []string {
+  return []string{ strconv.Itoa(r.Row), r.Applicant, r.Respondent, r.Status, r.Reason, r.Source }
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+  "time"
+
+  "github.com/NataRich/auto-case/court"
+)
+
+// 请求体定义在court包中
+type CaseBody = court.CaseBody
+type ApplicantBody = court.ApplicantBody
+type RespondentBody = court.RespondentBody
+
+// 由请求与调试配置生成调解平台客户端
+func NewClient(req *RequestConfig, debug *DebugConfig) *court.Client {
+  client := court.NewClient(court.StaticCookie(req.Cookie),
+                            time.Duration(req.Timeout) * time.Second)
+  client.Retry = req.Retry
+  client.Delay = time.Duration(req.Delay) * time.Second
+  client.Logger = Logger
+  client.Mask = Masking
+  client.Locale = CourtLocale
+
+  if req.Endpoint != "" {
+    client.Endpoint = req.Endpoint
+  }
+
+  if debug != nil {
+    client.Fake = debug.Fake
+  }
+
+  return client
+}
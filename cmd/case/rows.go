@@ -0,0 +1,254 @@
+package main
+
+import (
+  "io"
+  "os"
+  "fmt"
+  "bufio"
+  "strings"
+  "encoding/csv"
+  "path/filepath"
+
+  "github.com/xuri/excelize/v2"
+)
+
+// 该行所有单元格均为空
+var ErrBlankRow = NewError("err.blankRow")
+
+// 按数据源配置（指定行号或跳过列名行、跳过行数、执行行数，以及筛选表达式）逐行读取excel，
+// line为excel行号，无法读取的行与空行以rowErr传入fn
+func EachRow(data *DataConfig, fn func(line int, row []string, rowErr error) error) error {
+  lines, err := SelectRows(data)
+  if err != nil {
+    return err
+  }
+
+  return EachRowIn(data, lines, fn)
+}
+
+// 在提交前确定要处理的行号：指定行号中满足筛选表达式的各行；未指定行号时，
+// 从跳过的行之后开始，取满足筛选表达式的前execCount行（无筛选表达式时即为之后的execCount行）
+func SelectRows(data *DataConfig) ([]int, error) {
+  var set RowSet
+  if data.Rows != "" {
+    s, err := ParseRowSet(data.Rows)
+    if err != nil {
+      return nil, err
+    }
+    set = s
+  }
+
+  var filter *Filter
+  if data.Where != "" {
+    f, err := ParseFilter(data.Where)
+    if err != nil {
+      return nil, err
+    }
+    filter = f
+  }
+
+  // 未指定行号时的范围
+  first := data.SkipLines + 1
+  if data.SkipHeader {
+    first += 1
+  }
+  last := first + data.ExecCount - 1
+  if set != nil {
+    first, last = 1, set.Last()
+  } else if filter != nil {
+    // 执行行数按满足条件的行计，读到表尾为止
+    last = excelize.TotalRows
+  }
+  Logger.Debug(T("log.skipRows"), "path", data.Path, "sheet", data.Sheet, "skip", first - 1)
+
+  lines := []int{}
+  err := readRows(data, func(line int, row []string, rowErr error) bool {
+    if line > last {
+      return false
+    }
+
+    if line < first || (set != nil && !set.Contains(line)) {
+      return true
+    }
+
+    // 无法读取的行保留，处理时记为跳过
+    if rowErr == nil && filter != nil && !filter.Match(row) {
+      return true
+    }
+
+    lines = append(lines, line)
+    return set != nil || len(lines) < data.ExecCount
+  })
+  if err != nil {
+    return nil, err
+  }
+
+  if set != nil || filter != nil {
+    Logger.Info(T("log.rowsSelected"), "rows", data.Rows, "where", data.Where, "count", len(lines))
+  }
+
+  return lines, nil
+}
+
+// 按SelectRows确定的行号（升序）逐行读取excel
+func EachRowIn(data *DataConfig, lines []int, fn func(line int, row []string, rowErr error) error) error {
+  if len(lines) == 0 {
+    return nil
+  }
+
+  var fnErr error
+  i := 0
+  err := readRows(data, func(line int, row []string, rowErr error) bool {
+    if line != lines[i] {
+      return true
+    }
+
+    lg := RowLogger(data, line)
+    lg.Debug(T("log.readRow"), "path", data.Path)
+
+    if rowErr != nil {
+      lg.Error(T("log.rowUnreadable"), "err", rowErr)
+    } else if isBlankRow(row) {
+      rowErr = ErrBlankRow
+    }
+
+    if fnErr = fn(line, row, rowErr); fnErr != nil {
+      return false
+    }
+
+    i++
+    return i < len(lines)
+  })
+  if fnErr != nil {
+    return fnErr
+  }
+
+  return err
+}
+
+// 从第1行起依次读取工作表，fn返回false时停止
+func readRows(data *DataConfig, fn func(line int, row []string, rowErr error) bool) error {
+  if isCSV(data.Path) {
+    return readCSV(data, fn)
+  }
+
+  f, err := excelize.OpenFile(data.Path)
+  if err != nil {
+    return &DataError{ Path: data.Path, Err: err }
+  }
+
+  defer func() {
+    if err := f.Close(); err != nil {
+      Logger.Warn(T("log.closeBook"), "path", data.Path, "err", err)
+    }
+  }()
+
+  rows, err := f.Rows(data.Sheet)
+  if err != nil {
+    return &DataError{ Path: data.Path, Err: err }
+  }
+
+  defer rows.Close()
+
+  for line := 1; rows.Next(); line++ {
+    row, rowErr := rows.Columns()
+    if !fn(line, row, rowErr) {
+      break
+    }
+  }
+
+  if err := rows.Error(); err != nil {
+    return &DataError{ Path: data.Path, Err: err }
+  }
+
+  return nil
+}
+
+// 读取CSV文件（UTF-8，可带BOM），不区分工作表；空行不会传入fn
+func readCSV(data *DataConfig, fn func(line int, row []string, rowErr error) bool) error {
+  f, err := os.Open(data.Path)
+  if err != nil {
+    return &DataError{ Path: data.Path, Err: err }
+  }
+  defer f.Close()
+
+  r := csv.NewReader(bufio.NewReader(f))
+  r.FieldsPerRecord = -1
+  r.LazyQuotes = true
+
+  for first := true; ; first = false {
+    row, err := r.Read()
+    if err == io.EOF {
+      return nil
+    }
+
+    if err != nil {
+      return &DataError{ Path: data.Path, Err: err }
+    }
+
+    if first && len(row) > 0 {
+      row[0] = strings.TrimPrefix(row[0], "\uFEFF")
+    }
+
+    // 单元格中含换行时一条记录占多行，行号取记录的首行
+    line, _ := r.FieldPos(0)
+    if !fn(line, row, nil) {
+      return nil
+    }
+  }
+}
+
+// 是否为CSV文件
+func isCSV(path string) bool {
+  return strings.EqualFold(filepath.Ext(path), ".csv")
+}
+
+func isBlankRow(row []string) bool {
+  for _, cell := range row {
+    if strings.TrimSpace(cell) != "" {
+      return false
+    }
+  }
+
+  return true
+}
+
+// 由案件配置（按行选择配置方案）与一行数据生成该行的案件配置，并检查当事人信息
+func PrepareRow(cases *CaseSet, data *DataConfig, row []string, line int) (*CaseConfig, Issues) {
+  var issues Issues
+
+  base, name, issue := cases.Pick(data, row)
+  if issue != nil {
+    issue.Cell = fmt.Sprintf("%s%d", strings.ToUpper(data.ProfileCol), line)
+    issues = append(issues, issue)
+    return nil, issues.AtRow(line)
+  }
+
+  // 按行选择方案但该行未指定时，基础案件配置未经预先检查
+  if data.ProfileCol != "" && name == "" {
+    for _, issue := range CaseCheck(base, "case") {
+      if issue.Severity == SEVERITY_ERROR {
+        issues = append(issues, issue)
+      }
+    }
+
+    if issues.HasError() {
+      return nil, issues.AtRow(line)
+    }
+  }
+
+  ca := base.Copy()
+  cells, err := FillRow(ca, data, row, line)
+  if err != nil {
+    issues.Error("data.mapper", "DATA_MAPPER_INVALID", err)
+    return nil, issues.AtRow(line)
+  }
+
+  issues = append(issues, PartyCheck(ca, cells)...)
+  return ca, issues.AtRow(line)
+}
+
+// 规范当事人电话并检查当事人信息，cells为字段对应的单元格（不是来自excel时为空）
+func PartyCheck(ca *CaseConfig, cells map[string]string) Issues {
+  return issuesOf(CourtLocale.CheckParties(ca, cells))
+}
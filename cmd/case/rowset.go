@@ -0,0 +1,87 @@
+package main
+
+import (
+  "sort"
+  "strconv"
+  "strings"
+)
+
+// 行号范围（含首尾）
+type RowRange struct {
+  From        int
+  To          int
+}
+
+// excel行号集合，如 17,42,90-95
+type RowSet []RowRange
+
+// 解析以逗号分隔的行号与行号范围，结果按行号排序
+func ParseRowSet(s string) (RowSet, error) {
+  var set RowSet
+  for _, part := range strings.Split(s, ",") {
+    part = strings.TrimSpace(part)
+    if part == "" {
+      continue
+    }
+
+    from, to, err := ParseRowRange(part)
+    if err != nil {
+      return nil, err
+    }
+    set = append(set, RowRange{ From: from, To: to })
+  }
+
+  if len(set) == 0 {
+    return nil, NewError("err.rowRangeEmpty", s)
+  }
+
+  sort.Slice(set, func(i, j int) bool { return set[i].From < set[j].From })
+  return set, nil
+}
+
+// 解析单个行号或行号范围，如 17、90-95
+func ParseRowRange(s string) (int, int, error) {
+  from, to, ok := strings.Cut(strings.TrimSpace(s), "-")
+  if !ok {
+    to = from
+  }
+
+  start, err := strconv.Atoi(strings.TrimSpace(from))
+  if err != nil {
+    return 0, 0, NewError("err.rowRange", s)
+  }
+
+  end, err := strconv.Atoi(strings.TrimSpace(to))
+  if err != nil {
+    return 0, 0, NewError("err.rowRange", s)
+  }
+
+  if start < 1 || end < start {
+    return 0, 0, NewError("err.rowRangeEmpty", s)
+  }
+
+  return start, end, nil
+}
+
+// 是否包含该行
+func (set RowSet) Contains(line int) bool {
+  for _, r := range set {
+    if line >= r.From && line <= r.To {
+      return true
+    }
+  }
+
+  return false
+}
+
+// 最大行号
+func (set RowSet) Last() int {
+  last := 0
+  for _, r := range set {
+    if r.To > last {
+      last = r.To
+    }
+  }
+
+  return last
+}
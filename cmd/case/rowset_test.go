@@ -0,0 +1,98 @@
+package main
+
+import (
+  "testing"
+  "path/filepath"
+
+  "github.com/xuri/excelize/v2"
+)
+
+func TestParseRowSet(t *testing.T) {
+  set, err := ParseRowSet("90-95, 17,42")
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  for _, line := range []int{ 17, 42, 90, 93, 95 } {
+    if !set.Contains(line) {
+      t.Errorf("set does not contain %d", line)
+    }
+  }
+
+  for _, line := range []int{ 1, 18, 89, 96 } {
+    if set.Contains(line) {
+      t.Errorf("set contains %d", line)
+    }
+  }
+
+  if set.Last() != 95 {
+    t.Errorf("Last() = %d", set.Last())
+  }
+
+  for _, s := range []string{ "", ",", "0", "5-3", "a", "1-b" } {
+    if _, err := ParseRowSet(s); err == nil {
+      t.Errorf("ParseRowSet(%q) succeeded", s)
+    }
+  }
+}
+
+// 在临时目录中生成数据表
+func writeBook(t *testing.T, rows [][]interface{}) string {
+  f := excelize.NewFile()
+  for i, row := range rows {
+    cell, _ := excelize.CoordinatesToCellName(1, i + 1)
+    if err := f.SetSheetRow("Sheet1", cell, &row); err != nil {
+      t.Fatal(err)
+    }
+  }
+
+  path := filepath.Join(t.TempDir(), "data.xlsx")
+  if err := f.SaveAs(path); err != nil {
+    t.Fatal(err)
+  }
+  return path
+}
+
+func TestSelectRows(t *testing.T) {
+  path := writeBook(t, [][]interface{}{
+    { "申请人", "被申请人", "金额", "类型" },
+    { "张三", "李四", 3000, "劳动争议" },
+    { "王五", "赵六", 8000, "劳动争议" },
+    { },
+    { "孙七", "周八", 9000, "合同纠纷" },
+    { "吴九", "郑十", 6000, "劳动争议" },
+  })
+
+  tests := []struct {
+    name string
+    data DataConfig
+    want []int
+  }{
+    { "window", DataConfig{ SkipHeader: true, SkipLines: 1, ExecCount: 3 }, []int{ 3, 4, 5 } },
+    { "rows", DataConfig{ Rows: "6,2-3", ExecCount: 1 }, []int{ 2, 3, 6 } },
+    { "rows beyond sheet", DataConfig{ Rows: "5-100" }, []int{ 5, 6 } },
+    { "where", DataConfig{ SkipHeader: true, ExecCount: 10, Where: `C > 5000 && D == "劳动争议"` }, []int{ 3, 6 } },
+    { "rows and where", DataConfig{ Rows: "2-5", Where: `C > 5000` }, []int{ 3, 5 } },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      data := tt.data
+      data.Path, data.Sheet = path, "Sheet1"
+
+      got, err := SelectRows(&data)
+      if err != nil {
+        t.Fatal(err)
+      }
+
+      if len(got) != len(tt.want) {
+        t.Fatalf("got %v, want %v", got, tt.want)
+      }
+      for i := range got {
+        if got[i] != tt.want[i] {
+          t.Fatalf("got %v, want %v", got, tt.want)
+        }
+      }
+    })
+  }
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+  "time"
+  "context"
+  "strconv"
+  "strings"
+  "log/slog"
+)
+
+const (
+  // 每日新建数按本地日期统计
+  DAY_FORMAT = "2006-01-02"
+
+  // 查找下一个可提交时间时最多向后查找的天数
+  SCHEDULE_MAX_DAYS = 8
+
+  // 等待期间重新检查的间隔，以应对系统时间调整与休眠
+  SCHEDULE_CHECK = time.Minute
+)
+
+// 一天中的时间段（自零点起的分钟数，含起点不含终点）
+type Window struct {
+  From        int
+  To          int
+}
+
+// 解析时间段，如 09:00-11:30
+func ParseWindow(s string) (Window, error) {
+  from, to, ok := strings.Cut(s, "-")
+  if !ok {
+    return Window{}, NewError("err.window", s)
+  }
+
+  start, ok := parseClock(from)
+  if !ok {
+    return Window{}, NewError("err.window", s)
+  }
+
+  end, ok := parseClock(to)
+  if !ok || end <= start {
+    return Window{}, NewError("err.window", s)
+  }
+
+  return Window{ From: start, To: end }, nil
+}
+
+// 解析时刻，如 9:00、17:30、24:00
+func parseClock(s string) (int, bool) {
+  h, m, ok := strings.Cut(strings.TrimSpace(s), ":")
+  if !ok {
+    return 0, false
+  }
+
+  hour, err := strconv.Atoi(h)
+  if err != nil || hour < 0 || hour > 24 {
+    return 0, false
+  }
+
+  min, err := strconv.Atoi(m)
+  if err != nil || min < 0 || min > 59 || (hour == 24 && min > 0) {
+    return 0, false
+  }
+
+  return hour * 60 + min, true
+}
+
+// 提交时间安排检查
+func ScheduleCheck(sc *ScheduleConfig) Issues {
+  var issues Issues
+  for i, w := range sc.Windows {
+    if _, err := ParseWindow(w); err != nil {
+      issues.Error("schedule.windows[" + strconv.Itoa(i) + "]", "SCHEDULE_WINDOW_INVALID", err)
+    }
+  }
+
+  for i, d := range sc.Weekdays {
+    if d < 1 || d > 7 {
+      issues.Error("schedule.weekdays[" + strconv.Itoa(i) + "]", "SCHEDULE_WEEKDAY_INVALID", d)
+    }
+  }
+
+  if sc.DailyLimit < 0 {
+    issues.Error("schedule.dailyLimit", "SCHEDULE_LIMIT_NEGATIVE")
+  }
+
+  if sc.MediatorDailyLimit < 0 {
+    issues.Error("schedule.mediatorDailyLimit", "SCHEDULE_LIMIT_NEGATIVE")
+  }
+
+  return issues
+}
+
+// 按提交时间安排控制提交：等待允许的时间段，并按账号与调解员统计每天的新建数
+type Scheduler struct {
+  conf        *ScheduleConfig
+  ledger      *Ledger
+  windows     []Window
+
+  // 当前时间，便于测试
+  now         func() time.Time
+}
+
+// 新建提交控制，未配置提交时间安排时为空（不限制）
+func NewScheduler(sc *ScheduleConfig, ledger *Ledger) (*Scheduler, error) {
+  if sc == nil {
+    return nil, nil
+  }
+
+  s := &Scheduler{ conf: sc, ledger: ledger, now: time.Now }
+  for _, w := range sc.Windows {
+    win, err := ParseWindow(w)
+    if err != nil {
+      return nil, err
+    }
+    s.windows = append(s.windows, win)
+  }
+
+  return s, nil
+}
+
+// 账号的计数键
+func (s *Scheduler) accountKey() string {
+  account := s.conf.Account
+  if account == "" {
+    account = "default"
+  }
+  return "account:" + account
+}
+
+func mediatorKey(mediator string) string {
+  return "mediator:" + mediator
+}
+
+// 下一个允许为该调解员提交的时间（不早于当前时间），一周内都不允许时为零值
+func (s *Scheduler) Next(mediator string) time.Time {
+  t := s.now()
+  for i := 0; i < SCHEDULE_MAX_DAYS; i++ {
+    day := t.Format(DAY_FORMAT)
+    midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+    tomorrow := midnight.AddDate(0, 0, 1)
+
+    if s.conf.DailyLimit > 0 && s.ledger.Used(day, s.accountKey()) >= s.conf.DailyLimit {
+      t = tomorrow
+      continue
+    }
+
+    if s.conf.MediatorDailyLimit > 0 && s.ledger.Used(day, mediatorKey(mediator)) >= s.conf.MediatorDailyLimit {
+      t = tomorrow
+      continue
+    }
+
+    if !s.weekday(t) {
+      t = tomorrow
+      continue
+    }
+
+    if len(s.windows) == 0 {
+      return t
+    }
+
+    // 当前时间段内，或当天稍后的时间段开始时
+    min := t.Hour() * 60 + t.Minute()
+    next := -1
+    for _, w := range s.windows {
+      if min >= w.From && min < w.To {
+        return t
+      }
+
+      if w.From > min && (next < 0 || w.From < next) {
+        next = w.From
+      }
+    }
+
+    if next >= 0 {
+      return midnight.Add(time.Duration(next) * time.Minute)
+    }
+
+    t = tomorrow
+  }
+
+  return time.Time{}
+}
+
+func (s *Scheduler) weekday(t time.Time) bool {
+  if len(s.conf.Weekdays) == 0 {
+    return true
+  }
+
+  d := int(t.Weekday())
+  if d == 0 {
+    d = 7
+  }
+
+  for _, w := range s.conf.Weekdays {
+    if w == d {
+      return true
+    }
+  }
+
+  return false
+}
+
+// 预留的每日新建数，提交明确失败时释放
+type Reservation struct {
+  day         string
+  keys        []string
+}
+
+// 提交前调用：不在允许的时间段或已达每日上限时等待，然后预留当天的新建数；
+// 中断时返回ErrInterrupted，无法写入处理记录时不预留（记录日志后照常提交）
+func (s *Scheduler) Wait(ctx context.Context, mediator string, lg *slog.Logger) (*Reservation, error) {
+  var logged time.Time
+  for {
+    next := s.Next(mediator)
+    if next.IsZero() {
+      return nil, NewError("err.scheduleNever")
+    }
+
+    wait := next.Sub(s.now())
+    if wait <= 0 {
+      res := &Reservation{ day: s.now().Format(DAY_FORMAT), keys: []string{ s.accountKey(), mediatorKey(mediator) } }
+      ok, err := s.ledger.Reserve(res.day, map[string]int{
+        res.keys[0]: s.conf.DailyLimit,
+        res.keys[1]: s.conf.MediatorDailyLimit,
+      })
+      if err != nil {
+        lg.Error(T("log.quotaFailed"), "err", err)
+        return nil, nil
+      }
+
+      // 其他进程已用完额度时重新等待
+      if ok {
+        return res, nil
+      }
+      continue
+    }
+
+    if !next.Equal(logged) {
+      lg.Warn(T("log.scheduleWait"), "until", next.Format("2006-01-02 15:04"), "mediator", mediator)
+      logged = next
+    }
+
+    if wait > SCHEDULE_CHECK {
+      wait = SCHEDULE_CHECK
+    }
+
+    select {
+    case <-ctx.Done():
+      return nil, ErrInterrupted
+    case <-time.After(wait):
+    }
+  }
+}
+
+// 提交明确失败（未新建）时释放预留的新建数
+func (s *Scheduler) Release(res *Reservation) error {
+  if res == nil {
+    return nil
+  }
+  return s.ledger.Release(res.day, res.keys...)
+}
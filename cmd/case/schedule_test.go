@@ -0,0 +1,183 @@
+package main
+
+import (
+  "time"
+  "context"
+  "testing"
+  "path/filepath"
+  "net/http/httptest"
+)
+
+func TestParseWindow(t *testing.T) {
+  tests := []struct {
+    in      string
+    want    Window
+    ok      bool
+  }{
+    { "09:00-11:30", Window{ 540, 690 }, true },
+    { " 9:00 - 17:00 ", Window{ 540, 1020 }, true },
+    { "18:00-24:00", Window{ 1080, 1440 }, true },
+    { "11:30-09:00", Window{}, false },
+    { "09:00", Window{}, false },
+    { "9-17", Window{}, false },
+    { "09:60-10:00", Window{}, false },
+  }
+
+  for _, tt := range tests {
+    got, err := ParseWindow(tt.in)
+    if (err == nil) != tt.ok || got != tt.want {
+      t.Errorf("ParseWindow(%q) = %v, %v", tt.in, got, err)
+    }
+  }
+}
+
+func TestScheduleCheck(t *testing.T) {
+  codes := codesOf(ScheduleCheck(&ScheduleConfig{
+    Windows:    []string{ "09:00-11:30", "17:00" },
+    Weekdays:   []int{ 1, 8 },
+    DailyLimit: -1,
+  }))
+
+  want := map[string]string{
+    "SCHEDULE_WINDOW_INVALID":  "schedule.windows[1]",
+    "SCHEDULE_WEEKDAY_INVALID": "schedule.weekdays[1]",
+    "SCHEDULE_LIMIT_NEGATIVE":  "schedule.dailyLimit",
+  }
+  for code, path := range want {
+    if codes[code] != path {
+      t.Errorf("%s at %q, want %q", code, codes[code], path)
+    }
+  }
+}
+
+// 2026-10-19为周一
+func at(day int, hour int, min int) time.Time {
+  return time.Date(2026, 10, day, hour, min, 0, 0, time.Local)
+}
+
+func TestSchedulerNext(t *testing.T) {
+  ledger, err := OpenLedger(filepath.Join(t.TempDir(), LEDGER_FILE))
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  s, err := NewScheduler(&ScheduleConfig{
+    Windows:            []string{ "09:00-11:30", "14:00-17:00" },
+    Weekdays:           []int{ 1, 2, 3, 4, 5 },
+    DailyLimit:         3,
+    MediatorDailyLimit: 2,
+  }, ledger)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  tests := []struct {
+    name    string
+    now     time.Time
+    want    time.Time
+  }{
+    { "before window", at(19, 8, 0), at(19, 9, 0) },
+    { "in window", at(19, 10, 15), at(19, 10, 15) },
+    { "between windows", at(19, 12, 0), at(19, 14, 0) },
+    { "window end", at(19, 11, 30), at(19, 14, 0) },
+    { "after windows", at(19, 18, 0), at(20, 9, 0) },
+    { "friday evening", at(23, 18, 0), at(26, 9, 0) },
+    { "weekend", at(25, 10, 0), at(26, 9, 0) },
+  }
+
+  for _, tt := range tests {
+    s.now = func() time.Time { return tt.now }
+    if got := s.Next("m1"); !got.Equal(tt.want) {
+      t.Errorf("%s: Next = %v, want %v", tt.name, got, tt.want)
+    }
+  }
+
+  // 调解员m1当天已达上限，其他调解员不受影响
+  s.now = func() time.Time { return at(19, 10, 0) }
+  for i := 0; i < 2; i++ {
+    if _, err := s.Wait(context.Background(), "m1", Logger); err != nil {
+      t.Fatal(err)
+    }
+  }
+  if got := s.Next("m1"); !got.Equal(at(20, 9, 0)) {
+    t.Errorf("mediator limit: Next = %v", got)
+  }
+  if got := s.Next("m2"); !got.Equal(at(19, 10, 0)) {
+    t.Errorf("other mediator: Next = %v", got)
+  }
+
+  // 账号当天已达上限；明确失败时释放的额度可再次使用
+  res, err := s.Wait(context.Background(), "m2", Logger)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if err := s.Release(res); err != nil {
+    t.Fatal(err)
+  }
+  if got := s.Next("m2"); !got.Equal(at(19, 10, 0)) {
+    t.Errorf("released: Next = %v", got)
+  }
+  if _, err := s.Wait(context.Background(), "m2", Logger); err != nil {
+    t.Fatal(err)
+  }
+  if got := s.Next("m2"); !got.Equal(at(20, 9, 0)) {
+    t.Errorf("account limit: Next = %v", got)
+  }
+
+  // 重新打开处理记录后额度仍然有效
+  ledger, err = OpenLedger(ledger.path)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if got := ledger.Used("2026-10-19", "account:default"); got != 3 {
+    t.Errorf("Used = %d, want 3", got)
+  }
+}
+
+func TestSchedulerWaitInterrupted(t *testing.T) {
+  s, err := NewScheduler(&ScheduleConfig{ Windows: []string{ "09:00-10:00" } }, &Ledger{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  s.now = func() time.Time { return at(19, 12, 0) }
+
+  ctx, cancel := context.WithCancel(context.Background())
+  cancel()
+  if _, err := s.Wait(ctx, "m1", Logger); err != ErrInterrupted {
+    t.Errorf("Wait = %v, want %v", err, ErrInterrupted)
+  }
+}
+
+func TestNewCaseSchedule(t *testing.T) {
+  fc := &fakeCourt{ response: `{"code":"1"}` }
+  srv := httptest.NewServer(fc)
+  defer srv.Close()
+
+  path := setupRun(t, srv.URL, [][]interface{}{
+    { "申请人", "被申请人", "申请人电话" },
+    { "张三", "李四", "13800001111" },
+    { "王五", "赵六", "13800002222" },
+  })
+
+  Conf.Schedule = &ScheduleConfig{ Account: "clerk", DailyLimit: 100 }
+  if err := SaveConf(path); err != nil {
+    t.Fatal(err)
+  }
+
+  if err := newApp().Run([]string{ "case", "--config", path, "new" }); err != nil {
+    t.Fatal(err)
+  }
+
+  ledger, err := OpenLedger(LEDGER_FILE)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  day := time.Now().Format(DAY_FORMAT)
+  if got := ledger.Used(day, "account:clerk"); got != 2 {
+    t.Errorf("account count = %d, want 2", got)
+  }
+  if got := ledger.Used(day, "mediator:m1"); got != 2 {
+    t.Errorf("mediator count = %d, want 2", got)
+  }
+}
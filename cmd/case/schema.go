@@ -0,0 +1,151 @@
+package main
+
+import (
+  "os"
+  "fmt"
+  "reflect"
+  "strings"
+  "encoding/json"
+
+  _ "embed"
+
+  "github.com/urfave/cli/v2"
+
+  "github.com/NataRich/auto-case/internal/confdoc"
+)
+
+// 配置结构体源码，用于从注释中提取字段说明
+//go:embed config.go
+var configSource string
+
+// court包结构体的说明由其源码生成（见court_docs.go）
+//go:generate go run ../../internal/confdoc/gen ../../court/config.go court_docs.go
+
+// 结构体及字段说明（类型名 -> 字段名 -> 注释，空字段名为类型本身的注释）
+var confDocs = parseConfDocs()
+
+func parseConfDocs() confdoc.Docs {
+  docs := confdoc.Docs{}
+  for typ, fields := range courtDocs {
+    docs[typ] = fields
+  }
+
+  confdoc.Parse(configSource, docs)
+  return docs
+}
+
+// 字段说明
+func FieldDoc(t reflect.Type, field string) string {
+  return confDocs[t.Name()][field]
+}
+
+// 由配置结构体生成JSON Schema
+func ConfSchema() map[string]interface{} {
+  defs := map[string]interface{}{}
+  root := schemaOf(reflect.TypeOf(GlobalConfig{}), defs)
+
+  schema := map[string]interface{}{
+    "$schema":  "https://json-schema.org/draft/2020-12/schema",
+    "title":    "auto-case 配置文件",
+    "$defs":    defs,
+  }
+
+  for k, v := range root {
+    schema[k] = v
+  }
+
+  // 允许在配置文件中引用schema
+  props := schema["properties"].(map[string]interface{})
+  props["$schema"] = map[string]interface{}{ "type": "string" }
+  return schema
+}
+
+func schemaOf(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+  if t.Kind() == reflect.Ptr {
+    t = t.Elem()
+  }
+
+  switch t.Kind() {
+  case reflect.String:
+    return map[string]interface{}{ "type": "string" }
+  case reflect.Int:
+    return map[string]interface{}{ "type": "integer" }
+  case reflect.Bool:
+    return map[string]interface{}{ "type": "boolean" }
+  case reflect.Map:
+    // 配置方案为部分覆盖的全局配置
+    if t.Elem() == reflect.TypeOf(json.RawMessage{}) {
+      return map[string]interface{}{
+        "type": "object",
+        "additionalProperties": map[string]interface{}{ "$ref": "#" },
+      }
+    }
+    return map[string]interface{}{
+      "type": "object",
+      "additionalProperties": schemaOf(t.Elem(), defs),
+    }
+  case reflect.Slice:
+    // 多个数据源为部分覆盖的数据源配置
+    if t.Elem() == reflect.TypeOf(json.RawMessage{}) {
+      return map[string]interface{}{
+        "type": "array",
+        "items": map[string]interface{}{ "$ref": "#/$defs/DataConfig" },
+      }
+    }
+    return map[string]interface{}{
+      "type": "array",
+      "items": schemaOf(t.Elem(), defs),
+    }
+  case reflect.Struct:
+    props := map[string]interface{}{}
+    for i := 0; i < t.NumField(); i++ {
+      f := t.Field(i)
+      name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+      if name == "" || name == "-" {
+        continue
+      }
+
+      var prop map[string]interface{}
+      ft := f.Type
+      if ft.Kind() == reflect.Ptr && ft.Elem().Kind() == reflect.Struct {
+        ref := ft.Elem().Name()
+        if _, ok := defs[ref]; !ok {
+          defs[ref] = nil
+          defs[ref] = schemaOf(ft.Elem(), defs)
+        }
+        prop = map[string]interface{}{ "$ref": "#/$defs/" + ref }
+      } else {
+        prop = schemaOf(ft, defs)
+      }
+
+      if doc := FieldDoc(t, f.Name); doc != "" {
+        prop["description"] = doc
+      }
+      props[name] = prop
+    }
+
+    schema := map[string]interface{}{
+      "type": "object",
+      "properties": props,
+      "additionalProperties": false,
+    }
+
+    if doc := FieldDoc(t, ""); doc != "" {
+      schema["description"] = doc
+    }
+    return schema
+  }
+
+  return map[string]interface{}{}
+}
+
+// 输出配置文件的JSON Schema
+func printSchema(ctx *cli.Context) error {
+  data, err := json.MarshalIndent(ConfSchema(), "", "  ")
+  if err != nil {
+    return err
+  }
+
+  _, err = fmt.Fprintf(os.Stdout, "%s\n", data)
+  return err
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+  "os"
+  "bytes"
+  "testing"
+
+  "github.com/NataRich/auto-case/internal/confdoc"
+)
+
+// court_docs.go须与court/config.go一致
+func TestCourtDocs(t *testing.T) {
+  src, err := os.ReadFile("../../court/config.go")
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  docs := confdoc.Docs{}
+  confdoc.Parse(string(src), docs)
+
+  got, err := os.ReadFile("court_docs.go")
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if !bytes.Equal(got, confdoc.Render(docs)) {
+    t.Error("court_docs.go is out of date, run go generate ./cmd/case")
+  }
+}
+
+func TestSchemaDocs(t *testing.T) {
+  defs := ConfSchema()["$defs"].(map[string]interface{})
+  person := defs["PersonConfig"].(map[string]interface{})
+  tel := person["properties"].(map[string]interface{})["tel"].(map[string]interface{})
+  if tel["description"] != "手机号码" {
+    t.Errorf("PersonConfig.tel description = %v", tel["description"])
+  }
+
+  data := defs["DataConfig"].(map[string]interface{})
+  if data["description"] == nil {
+    t.Error("DataConfig has no description")
+  }
+}
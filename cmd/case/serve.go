@@ -0,0 +1,500 @@
+package main
+
+import (
+  "time"
+  "sync"
+  "bytes"
+  "errors"
+  "context"
+  "strings"
+  "net/http"
+  "crypto/rand"
+  "crypto/subtle"
+  "encoding/hex"
+  "encoding/json"
+
+  "github.com/urfave/cli/v2"
+
+  "github.com/NataRich/auto-case/court"
+)
+
+const (
+  // 请求体大小上限
+  SERVE_MAX_BODY = 1 << 20
+
+  // 停止服务时等待正在处理的请求的时长
+  SERVE_SHUTDOWN = 10 * time.Second
+
+  // 已完成的案件保留的时长与数量，之后查询返回404
+  SERVE_JOB_TTL = 24 * time.Hour
+  SERVE_MAX_FINISHED = 1000
+)
+
+// 接口提交的案件状态
+const (
+  JOB_QUEUED      = "queued"
+  JOB_SUBMITTING  = "submitting"
+  JOB_SUCCEEDED   = "succeeded"
+  JOB_INVALID     = "invalid"
+  JOB_FAILED      = "failed"
+  JOB_CANCELED    = "canceled"
+)
+
+// 默认接口服务配置
+func DefaultServe() *ServeConfig {
+  return &ServeConfig{
+    Addr:       "127.0.0.1:8765",
+    Token:      "",
+    QueueSize:  100,
+  }
+}
+
+// 补全接口服务配置中未填写的项
+func serveDefaults(sc *ServeConfig) *ServeConfig {
+  def := DefaultServe()
+  if sc == nil {
+    return def
+  }
+
+  res := *sc
+  if res.Addr == "" {
+    res.Addr = def.Addr
+  }
+
+  if res.QueueSize == 0 {
+    res.QueueSize = def.QueueSize
+  }
+
+  return &res
+}
+
+// 接口服务配置检查
+func ServeCheck(sc *ServeConfig) Issues {
+  var issues Issues
+  if sc.Token == "" {
+    issues.Error("serve.token", "SERVE_TOKEN_EMPTY", EnvName("serve", "token"))
+  }
+
+  if sc.QueueSize < 0 {
+    issues.Error("serve.queueSize", "SERVE_QUEUE_NEGATIVE")
+  }
+
+  return issues
+}
+
+// 接口提交的案件：与案件配置（case）相同的字段覆盖基础案件配置，
+// applicant与respondent中的字段覆盖默认当事人，profile选择配置方案
+type CaseRequest struct {
+  *CaseConfig
+
+  Profile     string          `json:"profile,omitempty"`
+  Applicant   *PersonConfig   `json:"applicant,omitempty"`
+  Respondent  *PersonConfig   `json:"respondent,omitempty"`
+}
+
+// 接口提交的案件及其处理状态
+type Job struct {
+  ID          string          `json:"id"`
+  Status      string          `json:"status"`
+  Profile     string          `json:"profile,omitempty"`
+  Applicant   string          `json:"applicant"`
+  Respondent  string          `json:"respondent"`
+
+  // 失败原因
+  Error       string          `json:"error,omitempty"`
+
+  CreatedAt   time.Time       `json:"createdAt"`
+  UpdatedAt   time.Time       `json:"updatedAt"`
+
+  ca          *CaseConfig
+}
+
+// 本地接口服务：检查接口提交的案件并排队，依次提交（与批量新建相同的提交过程）
+type Server struct {
+  conf        *GlobalConfig
+  token       string
+  submitter   *Submitter
+
+  mu          sync.Mutex
+  jobs        map[string]*Job
+  queue       chan *Job
+
+  // 已完成的案件编号（按完成顺序），过期或超出数量时从jobs中清除
+  finished    []string
+  jobTTL      time.Duration
+  maxFinished int
+
+  // 会话失效后不再接受新的案件
+  expired     error
+
+  // 处理队列结束时关闭
+  done        chan struct{}
+}
+
+func NewServer(conf *GlobalConfig, ledger *Ledger) (*Server, error) {
+  submitter, err := NewSubmitter(conf, ledger)
+  if err != nil {
+    return nil, err
+  }
+
+  sc := serveDefaults(conf.Serve)
+  return &Server{
+    conf:        conf,
+    token:       sc.Token,
+    submitter:   submitter,
+    jobs:        map[string]*Job{},
+    queue:       make(chan *Job, sc.QueueSize),
+    jobTTL:      SERVE_JOB_TTL,
+    maxFinished: SERVE_MAX_FINISHED,
+    done:        make(chan struct{}),
+  }, nil
+}
+
+// 接口：POST /cases 提交案件，GET /cases/{id} 查询状态；均须提供访问令牌
+func (s *Server) Handler() http.Handler {
+  mux := http.NewServeMux()
+  mux.HandleFunc("/cases", func(w http.ResponseWriter, r *http.Request) {
+    if r.Method != http.MethodPost {
+      writeError(w, http.StatusMethodNotAllowed, NewError("err.method", r.Method))
+      return
+    }
+    s.create(w, r)
+  })
+  mux.HandleFunc("/cases/", func(w http.ResponseWriter, r *http.Request) {
+    if r.Method != http.MethodGet {
+      writeError(w, http.StatusMethodNotAllowed, NewError("err.method", r.Method))
+      return
+    }
+    s.status(w, r, strings.TrimPrefix(r.URL.Path, "/cases/"))
+  })
+
+  return s.authorize(mux)
+}
+
+// 校验访问令牌
+func (s *Server) authorize(next http.Handler) http.Handler {
+  want := []byte("Bearer " + s.token)
+  return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    got := []byte(r.Header.Get("Authorization"))
+    if s.token == "" || subtle.ConstantTimeCompare(got, want) != 1 {
+      writeError(w, http.StatusUnauthorized, NewError("err.unauthorized"))
+      return
+    }
+    next.ServeHTTP(w, r)
+  })
+}
+
+// 提交案件：检查未通过时返回422及问题列表，否则排队并返回202及案件编号
+func (s *Server) create(w http.ResponseWriter, r *http.Request) {
+  body, err := readBody(w, r)
+  if err != nil {
+    writeError(w, http.StatusBadRequest, err)
+    return
+  }
+
+  ca, profile, err := s.parseCase(body)
+  if err != nil {
+    writeError(w, http.StatusBadRequest, err)
+    return
+  }
+
+  issues := CaseCheck(ca, "case")
+  issues = append(issues, PartyCheck(ca, nil)...)
+  if issues.HasError() {
+    writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+      "error":  T("err.validate"),
+      "issues": issues,
+    })
+    return
+  }
+
+  id, err := newJobID()
+  if err != nil {
+    writeError(w, http.StatusInternalServerError, err)
+    return
+  }
+
+  now := time.Now()
+  job := &Job{
+    ID:         id,
+    Status:     JOB_QUEUED,
+    Profile:    profile,
+    Applicant:  Masking.Name(ca.DefaultApplicant.Name),
+    Respondent: Masking.Name(ca.DefaultRespondent.Name),
+    CreatedAt:  now,
+    UpdatedAt:  now,
+    ca:         ca,
+  }
+
+  s.mu.Lock()
+  if s.expired != nil {
+    s.mu.Unlock()
+    writeError(w, http.StatusServiceUnavailable, s.expired)
+    return
+  }
+
+  select {
+  case s.queue <- job:
+    s.jobs[id] = job
+  default:
+    s.mu.Unlock()
+    writeError(w, http.StatusServiceUnavailable, NewError("err.queueFull"))
+    return
+  }
+  res := *job
+  s.mu.Unlock()
+
+  Logger.Info(T("log.jobQueued"), "job", id, "applicant", job.Applicant, "profile", profile)
+  writeJSON(w, http.StatusAccepted, &res)
+}
+
+// 由请求体生成案件配置：先确定配置方案，再以请求中的字段覆盖，未知字段视为错误
+func (s *Server) parseCase(body []byte) (*CaseConfig, string, error) {
+  var head struct {
+    Profile   string        `json:"profile"`
+  }
+  if err := json.Unmarshal(body, &head); err != nil {
+    return nil, "", NewError("err.requestBody", err)
+  }
+
+  conf := s.conf
+  if head.Profile != "" {
+    pc, err := ApplyProfile(conf, head.Profile)
+    if err != nil {
+      return nil, "", err
+    }
+    conf = pc
+  }
+
+  ca := &CaseConfig{}
+  if conf.Case != nil {
+    ca = conf.Case.Copy()
+  }
+
+  req := &CaseRequest{ CaseConfig: ca, Applicant: ca.DefaultApplicant, Respondent: ca.DefaultRespondent }
+  dec := json.NewDecoder(bytes.NewReader(body))
+  dec.DisallowUnknownFields()
+  if err := dec.Decode(req); err != nil {
+    return nil, "", NewError("err.requestBody", err)
+  }
+
+  ca.DefaultApplicant, ca.DefaultRespondent = req.Applicant, req.Respondent
+  return ca, head.Profile, nil
+}
+
+// 查询案件状态
+func (s *Server) status(w http.ResponseWriter, r *http.Request, id string) {
+  s.mu.Lock()
+  s.evict(time.Now())
+  job, ok := s.jobs[id]
+  var res Job
+  if ok {
+    res = *job
+  }
+  s.mu.Unlock()
+
+  if !ok {
+    writeError(w, http.StatusNotFound, NewError("err.jobUnknown", id))
+    return
+  }
+
+  writeJSON(w, http.StatusOK, &res)
+}
+
+// 依次提交排队的案件，直到中断；中断后仍在排队的案件标记为已取消
+func (s *Server) Run(control *Control) {
+  defer close(s.done)
+
+  ctx := control.Context()
+  for {
+    select {
+    case <-ctx.Done():
+      s.cancelQueued(ErrInterrupted)
+      return
+    case job := <-s.queue:
+      if err := control.Wait(); err != nil {
+        s.finish(job, JOB_CANCELED, err)
+        continue
+      }
+      s.process(ctx, job)
+    }
+  }
+}
+
+// 等待处理队列结束
+func (s *Server) Wait() {
+  <-s.done
+}
+
+func (s *Server) process(ctx context.Context, job *Job) {
+  lg := Logger.With("job", job.ID, "applicant", job.Applicant)
+
+  s.mu.Lock()
+  expired := s.expired
+  s.mu.Unlock()
+  if expired != nil {
+    s.finish(job, JOB_FAILED, expired)
+    return
+  }
+
+  res, err := s.submitter.Wait(ctx, job.ca, lg)
+  if err != nil {
+    s.finish(job, JOB_CANCELED, err)
+    return
+  }
+
+  s.finish(job, JOB_SUBMITTING, nil)
+  err = s.submitter.Submit(ctx, job.ca, res, lg)
+  if err == nil {
+    s.finish(job, JOB_SUCCEEDED, nil)
+    return
+  }
+
+  lg.Error(T("log.rowFailed"), "retry", s.conf.Request.Retry, "err", err)
+  s.finish(job, JOB_FAILED, err)
+
+  // 会话失效后的案件同样会失败，不再提交，待更新cookie后重启服务
+  if errors.Is(err, court.ErrSessionExpired) {
+    s.mu.Lock()
+    s.expired = err
+    s.mu.Unlock()
+  }
+}
+
+// 更新案件状态
+func (s *Server) finish(job *Job, status string, err error) {
+  s.mu.Lock()
+  defer s.mu.Unlock()
+
+  job.Status = status
+  job.Error = ""
+  if err != nil {
+    job.Error = err.Error()
+  }
+  job.UpdatedAt = time.Now()
+
+  switch status {
+  case JOB_SUCCEEDED, JOB_FAILED, JOB_CANCELED:
+    s.finished = append(s.finished, job.ID)
+    s.evict(job.UpdatedAt)
+  }
+}
+
+// 清除完成超过jobTTL或超出maxFinished个的已完成案件，调用时须持有s.mu
+func (s *Server) evict(now time.Time) {
+  for len(s.finished) > 0 {
+    id := s.finished[0]
+    job, ok := s.jobs[id]
+    if ok && len(s.finished) <= s.maxFinished && now.Sub(job.UpdatedAt) < s.jobTTL {
+      return
+    }
+
+    delete(s.jobs, id)
+    s.finished = s.finished[1:]
+  }
+}
+
+func (s *Server) cancelQueued(err error) {
+  for {
+    select {
+    case job := <-s.queue:
+      s.finish(job, JOB_CANCELED, err)
+    default:
+      return
+    }
+  }
+}
+
+func newJobID() (string, error) {
+  b := make([]byte, 8)
+  if _, err := rand.Read(b); err != nil {
+    return "", err
+  }
+  return hex.EncodeToString(b), nil
+}
+
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+  buf := &bytes.Buffer{}
+  if _, err := buf.ReadFrom(http.MaxBytesReader(w, r.Body, SERVE_MAX_BODY)); err != nil {
+    return nil, NewError("err.requestBody", err)
+  }
+  return buf.Bytes(), nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+  w.Header().Set("Content-Type", "application/json; charset=utf-8")
+  w.WriteHeader(code)
+
+  enc := json.NewEncoder(w)
+  enc.SetEscapeHTML(false)
+  enc.SetIndent("", "  ")
+  enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+  writeJSON(w, code, map[string]string{ "error": err.Error() })
+}
+
+// 启动本地接口服务，Ctrl-C 停止（正在提交的案件完成后退出）
+func serveCase(ctx *cli.Context) error {
+  if err := loadConf(ctx); err != nil {
+    return err
+  }
+
+  Conf.Serve = serveDefaults(Conf.Serve)
+
+  if ctx.IsSet("addr") {
+    Conf.Serve.Addr = ctx.String("addr")
+  }
+
+  // 案件与当事人信息可由接口请求提供，基础案件配置的问题只作为警告；不使用数据源配置
+  var issues Issues
+  for _, issue := range PreCheck(Conf) {
+    if issue.Path == "data" || strings.HasPrefix(issue.Path, "data.") {
+      continue
+    }
+
+    if strings.HasPrefix(issue.Path, "case") {
+      issue.Severity = SEVERITY_WARNING
+    }
+    issues = append(issues, issue)
+  }
+  issues = append(issues, ServeCheck(Conf.Serve)...)
+
+  issues.Log(Logger)
+  if issues.HasError() {
+    return NewError("err.precheck")
+  }
+
+  ledger, err := OpenLedger(Conf.LedgerPath())
+  if err != nil {
+    return err
+  }
+
+  server, err := NewServer(Conf, ledger)
+  if err != nil {
+    return err
+  }
+
+  control := NewControl(ctx.Context, false)
+  defer control.Stop()
+
+  go server.Run(control)
+
+  srv := &http.Server{ Addr: Conf.Serve.Addr, Handler: server.Handler() }
+  go func() {
+    <-control.Context().Done()
+    shutdown, cancel := context.WithTimeout(context.Background(), SERVE_SHUTDOWN)
+    defer cancel()
+    srv.Shutdown(shutdown)
+  }()
+
+  Logger.Info(T("log.serving"), "addr", Conf.Serve.Addr)
+  if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+    return err
+  }
+
+  server.Wait()
+  Logger.Info(T("log.serveStopped"))
+  return nil
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+  "time"
+  "context"
+  "strings"
+  "testing"
+  "net/http"
+  "encoding/json"
+  "path/filepath"
+  "net/http/httptest"
+)
+
+// 启动接口服务与新建案例接口的替身，返回接口服务地址
+func setupServe(t *testing.T, fc *fakeCourt) string {
+  court := httptest.NewServer(fc)
+  t.Cleanup(court.Close)
+
+  conf := validConf()
+  conf.Case.DefaultApplicant.Name = ""
+  conf.Case.DefaultRespondent.Name = ""
+  conf.Request.Endpoint = court.URL
+  conf.Request.Delay = 0
+  conf.Request.Retry = 1
+  conf.Serve.Token = "secret"
+  conf.Profiles = map[string]json.RawMessage{
+    "labor": json.RawMessage(`{"case":{"causeCode":"labor"}}`),
+  }
+
+  ledger, err := OpenLedger(filepath.Join(t.TempDir(), LEDGER_FILE))
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  s, err := NewServer(conf, ledger)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  control := NewControl(context.Background(), false)
+  go s.Run(control)
+  t.Cleanup(func() {
+    control.Stop()
+    s.Wait()
+  })
+
+  api := httptest.NewServer(s.Handler())
+  t.Cleanup(api.Close)
+  return api.URL
+}
+
+// 发送请求，返回状态码与JSON响应
+func call(t *testing.T, method string, url string, token string, body string) (int, map[string]interface{}) {
+  req, err := http.NewRequest(method, url, strings.NewReader(body))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if token != "" {
+    req.Header.Set("Authorization", "Bearer " + token)
+  }
+
+  resp, err := http.DefaultClient.Do(req)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer resp.Body.Close()
+
+  res := map[string]interface{}{}
+  if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+    t.Fatal(err)
+  }
+  return resp.StatusCode, res
+}
+
+func TestServeCreate(t *testing.T) {
+  fc := &fakeCourt{ response: `{"code":"1"}` }
+  url := setupServe(t, fc)
+
+  body := `{
+    "profile": "labor",
+    "dispute": "拖欠工资",
+    "applicant": { "name": "张三", "tel": "138 0000 1111" },
+    "respondent": { "name": "李四" }
+  }`
+
+  code, res := call(t, "POST", url + "/cases", "secret", body)
+  if code != http.StatusAccepted {
+    t.Fatalf("POST = %d %v", code, res)
+  }
+
+  id, _ := res["id"].(string)
+  if id == "" || res["status"] != JOB_QUEUED {
+    t.Fatalf("POST = %v", res)
+  }
+
+  // 等待提交完成
+  deadline := time.Now().Add(5 * time.Second)
+  for {
+    code, res = call(t, "GET", url + "/cases/" + id, "secret", "")
+    if code != http.StatusOK {
+      t.Fatalf("GET = %d %v", code, res)
+    }
+    if res["status"] == JOB_SUCCEEDED {
+      break
+    }
+    if res["status"] == JOB_FAILED || time.Now().After(deadline) {
+      t.Fatalf("GET = %v", res)
+    }
+    time.Sleep(10 * time.Millisecond)
+  }
+
+  if len(fc.bodies) != 1 {
+    t.Fatalf("court received %d cases, want 1", len(fc.bodies))
+  }
+
+  got := fc.bodies[0]
+  if got.CauseCode != "labor" || got.Dispute != "拖欠工资" {
+    t.Errorf("case = %q %q", got.CauseCode, got.Dispute)
+  }
+  app, resp := got.ApplicantList[0], got.RespondentList[0]
+  if app.Name != "张三" || app.Tel != "13800001111" || resp.Name != "李四" {
+    t.Errorf("parties = %+v %+v", app, resp)
+  }
+}
+
+func TestServeRejects(t *testing.T) {
+  fc := &fakeCourt{ response: `{"code":"1"}` }
+  url := setupServe(t, fc)
+
+  valid := `{"applicant":{"name":"张三"},"respondent":{"name":"李四"}}`
+  tests := []struct {
+    name    string
+    method  string
+    path    string
+    token   string
+    body    string
+    want    int
+  }{
+    { "no token", "POST", "/cases", "", valid, http.StatusUnauthorized },
+    { "wrong token", "POST", "/cases", "wrong", valid, http.StatusUnauthorized },
+    { "bad json", "POST", "/cases", "secret", `{`, http.StatusBadRequest },
+    { "unknown field", "POST", "/cases", "secret", `{"causeCod":"1"}`, http.StatusBadRequest },
+    { "unknown profile", "POST", "/cases", "secret", `{"profile":"x"}`, http.StatusBadRequest },
+    { "missing names", "POST", "/cases", "secret", `{}`, http.StatusUnprocessableEntity },
+    { "unknown id", "GET", "/cases/abc", "secret", "", http.StatusNotFound },
+    { "wrong method", "GET", "/cases", "secret", "", http.StatusMethodNotAllowed },
+  }
+
+  for _, tt := range tests {
+    code, res := call(t, tt.method, url + tt.path, tt.token, tt.body)
+    if code != tt.want || res["error"] == nil {
+      t.Errorf("%s: %d %v, want %d", tt.name, code, res, tt.want)
+    }
+  }
+
+  // 检查未通过时返回问题列表
+  _, res := call(t, "POST", url + "/cases", "secret", `{"applicant":{"name":"张三"}}`)
+  issues, _ := res["issues"].([]interface{})
+  if len(issues) == 0 || !strings.Contains(mustJSON(t, issues), "PARTY_NAME_EMPTY") {
+    t.Errorf("issues = %v", issues)
+  }
+
+  if len(fc.bodies) != 0 {
+    t.Errorf("court received %d cases, want 0", len(fc.bodies))
+  }
+}
+
+func mustJSON(t *testing.T, v interface{}) string {
+  data, err := json.Marshal(v)
+  if err != nil {
+    t.Fatal(err)
+  }
+  return string(data)
+}
+
+// 已完成的案件超出数量或保留时长后清除，查询返回404
+func TestServeEvict(t *testing.T) {
+  conf := validConf()
+  conf.Serve.Token = "secret"
+  s, err := NewServer(conf, &Ledger{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  s.maxFinished = 2
+
+  for _, id := range []string{ "a", "b", "c", "d" } {
+    s.jobs[id] = &Job{ ID: id, Status: JOB_QUEUED }
+  }
+  for _, id := range []string{ "a", "b", "c" } {
+    s.finish(s.jobs[id], JOB_SUCCEEDED, nil)
+  }
+
+  get := func(id string) int {
+    req := httptest.NewRequest("GET", "/cases/" + id, nil)
+    req.Header.Set("Authorization", "Bearer secret")
+    rec := httptest.NewRecorder()
+    s.Handler().ServeHTTP(rec, req)
+    return rec.Code
+  }
+
+  want := map[string]int{ "a": http.StatusNotFound, "b": http.StatusOK, "c": http.StatusOK, "d": http.StatusOK }
+  for id, code := range want {
+    if got := get(id); got != code {
+      t.Errorf("GET %s = %d, want %d", id, got, code)
+    }
+  }
+
+  // 完成超过保留时长的案件被清除，未完成的案件保留
+  s.mu.Lock()
+  s.jobs["b"].UpdatedAt = time.Now().Add(-SERVE_JOB_TTL)
+  s.mu.Unlock()
+  if got := get("b"); got != http.StatusNotFound {
+    t.Errorf("GET expired = %d", got)
+  }
+  if got := get("d"); got != http.StatusOK {
+    t.Errorf("GET queued = %d", got)
+  }
+}
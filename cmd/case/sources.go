@@ -0,0 +1,146 @@
+package main
+
+import (
+  "strings"
+  "encoding/json"
+  "path/filepath"
+
+  "github.com/xuri/excelize/v2"
+)
+
+// 数据源列表：未配置sources时为data本身，否则为sources中的各项；
+// 各项继承data中的配置，出现的字段覆盖之（mapper按列合并）
+func (data *DataConfig) List() ([]*DataConfig, error) {
+  if len(data.Sources) == 0 {
+    return []*DataConfig{ data }, nil
+  }
+
+  base := *data
+  base.Sources = nil
+  raw, err := json.Marshal(&base)
+  if err != nil {
+    return nil, err
+  }
+
+  list := make([]*DataConfig, 0, len(data.Sources))
+  for i, src := range data.Sources {
+    d := &DataConfig{}
+    if err := json.Unmarshal(raw, d); err != nil {
+      return nil, err
+    }
+
+    if err := json.Unmarshal(src, d); err != nil {
+      return nil, NewError("err.sourceFormat", i + 1, err)
+    }
+    list = append(list, d)
+  }
+
+  return list, nil
+}
+
+// 展开数据源：path中的通配符匹配多个工作簿，sheet为空时处理工作簿中的所有工作表（CSV文件不区分工作表）
+func (data *DataConfig) Expand() ([]*DataConfig, error) {
+  list, err := data.List()
+  if err != nil {
+    return nil, err
+  }
+
+  var res []*DataConfig
+  for _, src := range list {
+    paths := []string{ src.Path }
+    if hasGlob(src.Path) {
+      paths, err = globBooks(src.Path)
+      if err != nil {
+        return nil, err
+      }
+    }
+
+    for _, path := range paths {
+      d := *src
+      d.Path = path
+      if isCSV(path) {
+        d.Sheet = ""
+        res = append(res, &d)
+        continue
+      }
+
+      if d.Sheet != "" {
+        res = append(res, &d)
+        continue
+      }
+
+      sheets, err := sheetList(path)
+      if err != nil {
+        return nil, err
+      }
+
+      for _, sheet := range sheets {
+        s := d
+        s.Sheet = sheet
+        res = append(res, &s)
+      }
+    }
+  }
+
+  return res, nil
+}
+
+// 数据源名称，如 data.xlsx（Sheet1）、data.csv
+func (data *DataConfig) Label() string {
+  if data.Sheet == "" || isCSV(data.Path) {
+    return data.Path
+  }
+  return data.Path + T("issue.cell", data.Sheet)
+}
+
+// 是否有数据源按行选择配置方案
+func (data *DataConfig) UsesProfiles() bool {
+  list, err := data.List()
+  if err != nil {
+    return data.ProfileCol != ""
+  }
+
+  for _, src := range list {
+    if src.ProfileCol != "" {
+      return true
+    }
+  }
+
+  return false
+}
+
+func hasGlob(path string) bool {
+  return strings.ContainsAny(path, "*?[")
+}
+
+// 按通配符查找工作簿，忽略excel打开文件时产生的临时文件（~$开头）
+func globBooks(pattern string) ([]string, error) {
+  matches, err := filepath.Glob(pattern)
+  if err != nil {
+    return nil, &DataError{ Path: pattern, Err: err }
+  }
+
+  var paths []string
+  for _, path := range matches {
+    if !strings.HasPrefix(filepath.Base(path), "~$") {
+      paths = append(paths, path)
+    }
+  }
+
+  if len(paths) == 0 {
+    return nil, &DataError{ Path: pattern, Err: NewError("err.sourceNoMatch") }
+  }
+
+  return paths, nil
+}
+
+// 工作簿中的所有工作表
+func sheetList(path string) ([]string, error) {
+  f, err := excelize.OpenFile(path)
+  if err != nil {
+    return nil, &DataError{ Path: path, Err: err }
+  }
+  defer f.Close()
+
+  return f.GetSheetList(), nil
+}
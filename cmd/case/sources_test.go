@@ -0,0 +1,112 @@
+package main
+
+import (
+  "os"
+  "errors"
+  "testing"
+  "encoding/json"
+  "path/filepath"
+
+  "github.com/xuri/excelize/v2"
+)
+
+func TestDataList(t *testing.T) {
+  data := &DataConfig{
+    Sheet: "Sheet1", SkipHeader: true, ExecCount: 10, ApplicantCol: "A", RespondentCol: "B",
+    Mapper: map[string]string{ "applicant.tel": "C" },
+    Sources: []json.RawMessage{
+      json.RawMessage(`{ "path": "a.xlsx" }`),
+      json.RawMessage(`{ "path": "b.xlsx", "sheet": "", "skipLines": 2, "mapper": { "respondent.tel": "D" } }`),
+    },
+  }
+
+  list, err := data.List()
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if len(list) != 2 {
+    t.Fatalf("got %d sources", len(list))
+  }
+
+  a, b := list[0], list[1]
+  if a.Path != "a.xlsx" || a.Sheet != "Sheet1" || !a.SkipHeader || a.ExecCount != 10 || a.Sources != nil {
+    t.Errorf("a = %+v", a)
+  }
+
+  if b.Sheet != "" || b.SkipLines != 2 || b.Mapper["applicant.tel"] != "C" || b.Mapper["respondent.tel"] != "D" {
+    t.Errorf("b = %+v", b)
+  }
+
+  // 各数据源互不影响
+  if data.Mapper["respondent.tel"] != "" || a.Mapper["respondent.tel"] != "" {
+    t.Error("source mapping leaked into the base config")
+  }
+
+  data.Sources = append(data.Sources, json.RawMessage(`{ "skipLines": "x" }`))
+  if _, err := data.List(); err == nil {
+    t.Error("invalid source accepted")
+  }
+}
+
+func TestDataExpand(t *testing.T) {
+  dir := t.TempDir()
+  for _, name := range []string{ "a.xlsx", "b.xlsx" } {
+    f := excelize.NewFile()
+    f.NewSheet("二月")
+    if err := f.SaveAs(filepath.Join(dir, name)); err != nil {
+      t.Fatal(err)
+    }
+  }
+  os.WriteFile(filepath.Join(dir, "~$a.xlsx"), []byte("lock"), 0644)
+
+  data := &DataConfig{
+    Sheet: "Sheet1",
+    Sources: []json.RawMessage{
+      json.RawMessage(`{ "path": "` + filepath.ToSlash(filepath.Join(dir, "*.xlsx")) + `" }`),
+      json.RawMessage(`{ "path": "` + filepath.ToSlash(filepath.Join(dir, "b.xlsx")) + `", "sheet": "" }`),
+    },
+  }
+
+  list, err := data.Expand()
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  var got []string
+  for _, d := range list {
+    got = append(got, filepath.Base(d.Path) + ":" + d.Sheet)
+  }
+
+  want := []string{ "a.xlsx:Sheet1", "b.xlsx:Sheet1", "b.xlsx:Sheet1", "b.xlsx:二月" }
+  if len(got) != len(want) {
+    t.Fatalf("got %v, want %v", got, want)
+  }
+  for i := range got {
+    if got[i] != want[i] {
+      t.Fatalf("got %v, want %v", got, want)
+    }
+  }
+
+  data.Sources = []json.RawMessage{ json.RawMessage(`{ "path": "` + filepath.ToSlash(filepath.Join(dir, "*.csv")) + `" }`) }
+  var dataErr *DataError
+  if _, err := data.Expand(); !errors.As(err, &dataErr) {
+    t.Errorf("err = %v, want a DataError", err)
+  }
+}
+
+func TestPreCheckSources(t *testing.T) {
+  conf := validConf()
+  conf.Data.Path = ""
+  conf.Data.Sheet = ""
+  conf.Data.Sources = []json.RawMessage{
+    json.RawMessage(`{ "path": "incoming/*.xlsx" }`),
+    json.RawMessage(`{ "sheet": "一月", "applicantCol": "1" }`),
+  }
+
+  codes := codesOf(PreCheck(conf))
+  if len(codes) != 2 || codes["DATA_PATH_EMPTY"] != "data.sources[1].path" ||
+     codes["DATA_APPLICANT_COL_INVALID"] != "data.sources[1].applicantCol" {
+    t.Errorf("issues: %v", codes)
+  }
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+  "io"
+  "os"
+  "fmt"
+  "context"
+  "strings"
+  "log/slog"
+  "encoding/json"
+  "text/tabwriter"
+
+  "github.com/urfave/cli/v2"
+  "github.com/xuri/excelize/v2"
+
+  "github.com/NataRich/auto-case/court"
+)
+
+// 问题级别
+type Severity string
+
+const (
+  SEVERITY_ERROR   Severity = "error"
+  SEVERITY_WARNING Severity = "warning"
+)
+
+// 单条检查问题
+type Issue struct {
+  // 数据源（多个数据源时），如 data.xlsx（Sheet1）
+  Source      string          `json:"source,omitempty"`
+
+  // 数据行号（配置问题为0）
+  Row         int             `json:"row,omitempty"`
+
+  // 字段路径，如 case.causeCode、applicant.tel
+  Path        string          `json:"path"`
+
+  // 单元格，如 C17
+  Cell        string          `json:"cell,omitempty"`
+
+  // 级别
+  Severity    Severity        `json:"severity"`
+
+  // 问题编号，不随界面语言变化，如 CASE_CAUSE_EMPTY
+  Code        string          `json:"code"`
+
+  // 说明
+  Message     string          `json:"message"`
+}
+
+// 检查结果
+type Issues []*Issue
+
+// 新建问题，说明由问题编号在消息目录中查得
+func NewIssue(sev Severity, path string, code string, args ...interface{}) *Issue {
+  return &Issue{ Path: path, Severity: sev, Code: code, Message: T(code, args...) }
+}
+
+func (is *Issues) add(sev Severity, path string, code string, args []interface{}) *Issue {
+  issue := NewIssue(sev, path, code, args...)
+  *is = append(*is, issue)
+  return issue
+}
+
+// 添加错误
+func (is *Issues) Error(path string, code string, args ...interface{}) *Issue {
+  return is.add(SEVERITY_ERROR, path, code, args)
+}
+
+// 添加警告
+func (is *Issues) Warn(path string, code string, args ...interface{}) *Issue {
+  return is.add(SEVERITY_WARNING, path, code, args)
+}
+
+// 由court包检查当事人信息得到的问题
+func issuesOf(problems []*court.Problem) Issues {
+  var issues Issues
+  for _, p := range problems {
+    severity := SEVERITY_ERROR
+    if p.Warning {
+      severity = SEVERITY_WARNING
+    }
+
+    issue := issues.add(severity, p.Path, p.Code, p.Args)
+    issue.Cell = p.Cell
+  }
+
+  return issues
+}
+
+// 标记所属数据行
+func (is Issues) AtRow(line int) Issues {
+  for _, issue := range is {
+    issue.Row = line
+  }
+
+  return is
+}
+
+// 标记所属数据源
+func (is Issues) In(source string) Issues {
+  for _, issue := range is {
+    issue.Source = source
+  }
+
+  return is
+}
+
+// 是否存在错误
+func (is Issues) HasError() bool {
+  for _, issue := range is {
+    if issue.Severity == SEVERITY_ERROR {
+      return true
+    }
+  }
+
+  return false
+}
+
+// 指定配置段内是否存在错误
+func (is Issues) HasErrorIn(section string) bool {
+  for _, issue := range is {
+    if issue.Severity == SEVERITY_ERROR &&
+       (issue.Path == section || strings.HasPrefix(issue.Path, section + ".")) {
+      return true
+    }
+  }
+
+  return false
+}
+
+// 错误与警告数量
+func (is Issues) Count() (errs int, warns int) {
+  for _, issue := range is {
+    if issue.Severity == SEVERITY_ERROR {
+      errs++
+    } else {
+      warns++
+    }
+  }
+
+  return errs, warns
+}
+
+func (issue *Issue) String() string {
+  var b strings.Builder
+  if issue.Severity == SEVERITY_ERROR {
+    fmt.Fprintf(&b, "[%s] ", T("issue.error"))
+  } else {
+    fmt.Fprintf(&b, "[%s] ", T("issue.warning"))
+  }
+
+  if issue.Source != "" {
+    fmt.Fprintf(&b, "%s ", issue.Source)
+  }
+
+  if issue.Row > 0 {
+    fmt.Fprintf(&b, "%s ", T("issue.row", issue.Row))
+  }
+
+  if issue.Path != "" {
+    b.WriteString(issue.Path)
+    if issue.Cell != "" {
+      b.WriteString(T("issue.cell", issue.Cell))
+    }
+    b.WriteString(T("issue.sep"))
+  }
+
+  b.WriteString(issue.Message)
+  return b.String()
+}
+
+// 以表格形式输出
+func (is Issues) PrintTable(w io.Writer) error {
+  tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+  fmt.Fprintln(tw, T("issue.header"))
+  for _, issue := range is {
+    row := "-"
+    if issue.Row > 0 {
+      row = fmt.Sprintf("%d", issue.Row)
+    }
+
+    if issue.Source != "" {
+      row = issue.Source + ":" + row
+    }
+
+    fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+                issue.Severity, issue.Code, row, issue.Path, issue.Cell, issue.Message)
+  }
+
+  errs, warns := is.Count()
+  fmt.Fprintf(tw, "\n%s\n", T("issue.total", errs, warns))
+  return tw.Flush()
+}
+
+// 以JSON形式输出
+func (is Issues) PrintJSON(w io.Writer) error {
+  if is == nil {
+    is = Issues{}
+  }
+
+  data, err := json.MarshalIndent(is, "", "  ")
+  if err != nil {
+    return err
+  }
+
+  _, err = fmt.Fprintf(w, "%s\n", data)
+  return err
+}
+
+// 逐条写入日志，错误为error级别，警告为warn级别
+func (is Issues) Log(lg *slog.Logger) {
+  for _, issue := range is {
+    level := slog.LevelWarn
+    if issue.Severity == SEVERITY_ERROR {
+      level = slog.LevelError
+    }
+
+    // 行号由调用方的日志上下文提供
+    attrs := []any{ "code", issue.Code, "path", issue.Path }
+    if issue.Cell != "" {
+      attrs = append(attrs, "cell", issue.Cell)
+    }
+
+    lg.Log(context.Background(), level, issue.Message, attrs...)
+  }
+}
+
+// 检查配置文件与所有数据行（跳过的行除外，指定--rows或--count时按其确定），不发送请求
+func validateCase(ctx *cli.Context) error {
+  if err := loadConf(ctx); err != nil {
+    return err
+  }
+
+  issues := PreCheck(Conf)
+  cases, err := NewCaseSet(Conf)
+  if err != nil {
+    issues.Error("profiles", "PROFILE_INVALID", err)
+  } else if Conf.Case != nil && Conf.Data != nil && !issues.HasErrorIn("data") {
+    sources, err := Conf.Data.Expand()
+    if err != nil {
+      issues.Error("data.path", "DATA_UNREADABLE", err)
+    }
+
+    if !ctx.IsSet("count") {
+      for _, src := range sources {
+        src.ExecCount = excelize.TotalRows
+      }
+    }
+
+    issues = append(issues, ValidateRows(cases, sources)...)
+  }
+
+  switch ctx.String("format") {
+  case "json":
+    if err := issues.PrintJSON(os.Stdout); err != nil {
+      return err
+    }
+  case "table":
+    if err := issues.PrintTable(os.Stdout); err != nil {
+      return err
+    }
+  default:
+    return NewError("err.format", ctx.String("format"))
+  }
+
+  if issues.HasError() {
+    return NewError("err.validate")
+  }
+
+  return nil
+}
+
+// 逐行检查各数据源（不提交），多个数据源时问题标注所在数据源
+func ValidateRows(cases *CaseSet, sources []*DataConfig) Issues {
+  var issues Issues
+  for _, src := range sources {
+    label := ""
+    if len(sources) > 1 {
+      label = src.Label()
+    }
+
+    err := EachRow(src, func(line int, row []string, rowErr error) error {
+      if rowErr == ErrBlankRow {
+        return nil
+      }
+
+      if rowErr != nil {
+        issue := issues.Error("", "ROW_UNREADABLE", rowErr)
+        issue.Source, issue.Row = label, line
+        return nil
+      }
+
+      _, rowIssues := PrepareRow(cases, src, row, line)
+      issues = append(issues, rowIssues.In(label)...)
+      return nil
+    })
+
+    if err != nil {
+      issues.Error("data.path", "DATA_UNREADABLE", err).Source = label
+    }
+  }
+
+  return issues
+}
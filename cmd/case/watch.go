@@ -0,0 +1,439 @@
+package main
+
+import (
+  "os"
+  "time"
+  "errors"
+  "strings"
+  "log/slog"
+  "encoding/json"
+  "path/filepath"
+
+  "github.com/urfave/cli/v2"
+  "github.com/xuri/excelize/v2"
+
+  "github.com/NataRich/auto-case/court"
+)
+
+// 监视目录下的子目录
+const (
+  WATCH_PROCESSED = "processed"
+  WATCH_FAILED    = "failed"
+  WATCH_RESULTS   = "results"
+)
+
+// 默认监视配置
+func DefaultWatch() *WatchConfig {
+  return &WatchConfig{
+    Interval:   10,
+    Settle:     5,
+    Profiles:   map[string]string{},
+  }
+}
+
+// 监视目录：发现新的工作簿（.xlsx、.csv）后检查并提交，结果写入results/，
+// 原文件移入processed/（全部成功）或failed/；处理记录保证同一文件不会被重复处理
+type Watcher struct {
+  dir         string
+  conf        *GlobalConfig
+  watch       *WatchConfig
+  ledger      *Ledger
+  control     *Control
+
+  // 待处理文件（文件名 -> 上次扫描时的状态）
+  pending     map[string]*pendingFile
+}
+
+// 待处理文件的大小与修改时间，保持不变一段时间后才处理
+type pendingFile struct {
+  size        int64
+  mod         time.Time
+  since       time.Time
+}
+
+func NewWatcher(dir string, conf *GlobalConfig, ledger *Ledger, control *Control) *Watcher {
+  watch := conf.Watch
+  if watch == nil {
+    watch = DefaultWatch()
+  }
+
+  return &Watcher{
+    dir:      dir,
+    conf:     conf,
+    watch:    watch,
+    ledger:   ledger,
+    control:  control,
+    pending:  map[string]*pendingFile{},
+  }
+}
+
+// 监视配置检查
+func WatchCheck(conf *GlobalConfig) Issues {
+  var issues Issues
+  watch := conf.Watch
+  if watch == nil {
+    return issues
+  }
+
+  if watch.Interval <= 0 {
+    issues.Error("watch.interval", "WATCH_INTERVAL_INVALID")
+  }
+
+  if watch.Settle < 0 {
+    issues.Error("watch.settle", "WATCH_SETTLE_NEGATIVE")
+  }
+
+  for pattern, name := range watch.Profiles {
+    path := "watch.profiles." + pattern
+    if _, err := filepath.Match(pattern, ""); err != nil {
+      issues.Error(path, "WATCH_PATTERN_INVALID", err)
+    }
+
+    if _, ok := conf.Profiles[name]; !ok {
+      issues.Error(path, "PROFILE_UNKNOWN", name)
+    }
+  }
+
+  return issues
+}
+
+// 持续监视，直到中断（空闲时中断视为正常停止）或会话失效
+func (w *Watcher) Run() error {
+  for _, sub := range []string{ WATCH_PROCESSED, WATCH_FAILED, WATCH_RESULTS } {
+    if err := os.MkdirAll(filepath.Join(w.dir, sub), 0755); err != nil {
+      return err
+    }
+  }
+
+  Logger.Info(T("log.watching"), "dir", w.dir, "interval", w.watch.Interval, "ledger", w.ledger.path)
+  for {
+    if err := w.Scan(); err != nil {
+      return err
+    }
+
+    select {
+    case <-w.control.Context().Done():
+      Logger.Info(T("log.watchStopped"))
+      return nil
+    case <-time.After(time.Duration(w.watch.Interval) * time.Second):
+    }
+  }
+}
+
+// 扫描一次目录，处理已就绪的文件；中断或会话失效时返回错误
+func (w *Watcher) Scan() error {
+  names, err := w.ready()
+  if err != nil {
+    return err
+  }
+
+  for _, name := range names {
+    if err := w.control.Wait(); err != nil {
+      return err
+    }
+
+    if err := w.Process(name); err != nil {
+      return err
+    }
+  }
+
+  return nil
+}
+
+// 大小与修改时间在settle秒内（且至少一次扫描间隔内）保持不变的文件
+func (w *Watcher) ready() ([]string, error) {
+  entries, err := os.ReadDir(w.dir)
+  if err != nil {
+    return nil, err
+  }
+
+  now := time.Now()
+  settle := time.Duration(w.watch.Settle) * time.Second
+  seen := map[string]bool{}
+
+  var names []string
+  for _, entry := range entries {
+    name := entry.Name()
+    if entry.IsDir() || !isWatched(name) {
+      continue
+    }
+
+    info, err := entry.Info()
+    if err != nil {
+      continue
+    }
+    seen[name] = true
+
+    p, ok := w.pending[name]
+    if !ok || p.size != info.Size() || !p.mod.Equal(info.ModTime()) {
+      w.pending[name] = &pendingFile{ size: info.Size(), mod: info.ModTime(), since: now }
+      continue
+    }
+
+    if now.Sub(p.since) >= settle {
+      names = append(names, name)
+      delete(w.pending, name)
+    }
+  }
+
+  for name := range w.pending {
+    if !seen[name] {
+      delete(w.pending, name)
+    }
+  }
+
+  return names, nil
+}
+
+// 是否为要处理的文件：忽略隐藏文件与excel打开文件时产生的临时文件（~$开头）
+func isWatched(name string) bool {
+  if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "~$") {
+    return false
+  }
+
+  ext := strings.ToLower(filepath.Ext(name))
+  return ext == ".xlsx" || ext == ".csv"
+}
+
+// 按文件名匹配配置方案，未匹配时为空（使用基础配置）；多个通配符匹配时取最长者
+func (w *Watcher) profileFor(name string) string {
+  profile, best := "", -1
+  for pattern, p := range w.watch.Profiles {
+    if ok, _ := filepath.Match(pattern, name); ok && len(pattern) > best {
+      profile, best = p, len(pattern)
+    }
+  }
+
+  return profile
+}
+
+// 该文件使用的配置：叠加配置方案，数据源替换为该文件（继承data中的配置）；
+// 未指定行号时处理跳过的行之后的所有行，不受执行行数限制
+func (w *Watcher) confFor(path string, profile string) (*GlobalConfig, error) {
+  conf := w.conf
+  if profile != "" {
+    pc, err := ApplyProfile(conf, profile)
+    if err != nil {
+      return nil, err
+    }
+    conf = pc
+  }
+
+  src, err := json.Marshal(map[string]interface{}{ "path": path, "execCount": excelize.TotalRows })
+  if err != nil {
+    return nil, err
+  }
+
+  res := *conf
+  data := DataConfig{}
+  if conf.Data != nil {
+    data = *conf.Data
+  }
+  data.Sources = []json.RawMessage{ src }
+  res.Data = &data
+
+  return &res, nil
+}
+
+// 处理一个文件，文件本身的问题记入处理记录并移入failed/，
+// 只有中断、会话失效与无法写入处理记录时返回错误
+func (w *Watcher) Process(name string) error {
+  path := filepath.Join(w.dir, name)
+  base := strings.TrimSuffix(name, filepath.Ext(name))
+  lg := Logger.With("file", name)
+
+  digest, err := FileDigest(path)
+  if err != nil {
+    lg.Error(T("log.watchUnreadable"), "err", err)
+    return nil
+  }
+
+  // 已开始提交的文件（包括提交中途退出的文件）不再处理，只在检查阶段失败的文件可重新处理
+  if prev := w.ledger.File(digest); prev != nil && prev.Blocks() {
+    lg.Warn(T("log.watchDuplicate"), "previous", prev.Name, "state", prev.State, "startedAt", prev.StartedAt)
+    w.move(path, WATCH_FAILED, lg)
+    return nil
+  }
+
+  profile := w.profileFor(name)
+  lg.Info(T("log.watchFile"), "profile", profile)
+
+  // 检查未通过时不提交任何行
+  conf, err := w.confFor(path, profile)
+  if err != nil {
+    return w.fail(digest, path, profile, EXIT_CONFIG, err, nil, false, lg)
+  }
+
+  issues := PreCheck(conf)
+  var cases *CaseSet
+  var sources []*DataConfig
+  if !issues.HasError() {
+    if cases, err = NewCaseSet(conf); err != nil {
+      issues.Error("profiles", "PROFILE_INVALID", err)
+    } else if sources, err = conf.Data.Expand(); err != nil {
+      issues.Error("data.path", "DATA_UNREADABLE", err)
+    } else {
+      issues = append(issues, ValidateRows(cases, sources)...)
+    }
+  }
+
+  if issues.HasError() {
+    issues.Log(lg)
+    code := EXIT_CONFIG
+    for _, issue := range issues {
+      if issue.Code == "DATA_UNREADABLE" {
+        code = EXIT_DATA
+      }
+    }
+    return w.fail(digest, path, profile, code, NewError("err.validate"), w.saveIssues(base, issues, lg), false, lg)
+  }
+
+  // 检查期间可能已被其他进程认领
+  if err := w.ledger.Begin(digest, name, profile); errors.Is(err, ErrFileClaimed) {
+    lg.Warn(T("log.watchDuplicate"))
+    w.move(path, WATCH_FAILED, lg)
+    return nil
+  } else if err != nil {
+    return err
+  }
+
+  summary, err := RunBatch(conf, cases, sources, w.control, w.ledger)
+  if summary == nil {
+    return w.fail(digest, path, profile, ExitCode(err), err, nil, true, lg)
+  }
+
+  summary.Print(os.Stdout)
+  results := w.saveSummary(base, summary, lg)
+
+  res := LedgerFile{ Name: name, Profile: profile, State: FILE_PROCESSED, ExitCode: summary.ExitCode,
+                     Submitted: summary.Submitted, Results: results }
+  sub := WATCH_PROCESSED
+  if err != nil {
+    res.State, res.Reason, sub = FILE_FAILED, err.Error(), WATCH_FAILED
+  }
+
+  if err := w.ledger.Finish(digest, res); err != nil {
+    return err
+  }
+  w.move(path, sub, lg)
+
+  // 中断或会话失效时停止监视，其余文件留待下次处理
+  if errors.Is(err, ErrInterrupted) || errors.Is(err, court.ErrSessionExpired) {
+    return err
+  }
+
+  return nil
+}
+
+// 记录失败并移入failed/，claimed为已认领（调用过Begin）
+func (w *Watcher) fail(digest string, path string, profile string, code int, err error, results []string, claimed bool, lg *slog.Logger) error {
+  lg.Error(T("log.watchFailed"), "exit", code, "err", err)
+  res := LedgerFile{ Name: filepath.Base(path), Profile: profile, State: FILE_FAILED, ExitCode: code,
+                     Reason: err.Error(), Results: results }
+
+  record := w.ledger.Reject
+  if claimed {
+    record = w.ledger.Finish
+  }
+  if err := record(digest, res); err != nil {
+    return err
+  }
+
+  w.move(path, WATCH_FAILED, lg)
+  return nil
+}
+
+// 将文件移入子目录，重名时加上时间后缀
+func (w *Watcher) move(path string, sub string, lg *slog.Logger) {
+  name := filepath.Base(path)
+  dest := filepath.Join(w.dir, sub, name)
+  if _, err := os.Stat(dest); err == nil {
+    ext := filepath.Ext(name)
+    dest = filepath.Join(w.dir, sub, strings.TrimSuffix(name, ext) + "-" + time.Now().Format("20060102-150405") + ext)
+  }
+
+  if err := os.Rename(path, dest); err != nil {
+    lg.Error(T("log.watchMove"), "to", dest, "err", err)
+    return
+  }
+
+  lg.Info(T("log.watchMoved"), "to", dest)
+}
+
+// 检查问题写入results/，返回写入的文件
+func (w *Watcher) saveIssues(base string, issues Issues, lg *slog.Logger) []string {
+  path := filepath.Join(w.dir, WATCH_RESULTS, base + ".issues.json")
+  f, err := os.Create(path)
+  if err == nil {
+    err = issues.PrintJSON(f)
+    f.Close()
+  }
+
+  if err != nil {
+    lg.Error(T("log.reportFailed"), "err", err)
+    return nil
+  }
+
+  return []string{ path }
+}
+
+// 运行报告与结果工作簿写入results/，返回写入的文件
+func (w *Watcher) saveSummary(base string, summary *Summary, lg *slog.Logger) []string {
+  dir := filepath.Join(w.dir, WATCH_RESULTS)
+  files, err := summary.SaveAs(dir, base + ".report")
+  if err != nil {
+    lg.Error(T("log.reportFailed"), "err", err)
+  }
+
+  book := filepath.Join(dir, base + ".result.xlsx")
+  if err := summary.SaveWorkbook(book); err != nil {
+    lg.Error(T("log.reportFailed"), "err", err)
+  } else {
+    files = append(files, book)
+  }
+
+  lg.Info(T("log.reportSaved"), "files", strings.Join(files, ", "))
+  return files
+}
+
+// 监视目录，处理其中新的工作簿
+func watchCase(ctx *cli.Context) error {
+  dir := ctx.Args().First()
+  if dir == "" {
+    return NewError("err.watchDir")
+  }
+
+  if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+    return NewError("err.watchDir")
+  }
+
+  if err := loadConf(ctx); err != nil {
+    return err
+  }
+
+  // 先检查与文件无关的配置，以免每个文件都因同样的问题失败
+  w := &Watcher{ dir: dir, conf: Conf }
+  issues := WatchCheck(Conf)
+  if conf, err := w.confFor(filepath.Join(dir, "*"), ""); err == nil {
+    for _, issue := range PreCheck(conf) {
+      if !strings.HasPrefix(issue.Path, "data.sources") {
+        issues = append(issues, issue)
+      }
+    }
+  }
+
+  issues.Log(Logger)
+  if issues.HasError() {
+    return NewError("err.precheck")
+  }
+
+  ledger, err := OpenLedger(Conf.LedgerPath())
+  if err != nil {
+    return err
+  }
+
+  control := NewControl(ctx.Context, StdinIsTerminal())
+  defer control.Stop()
+
+  return NewWatcher(dir, Conf, ledger, control).Run()
+}
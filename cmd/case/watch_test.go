@@ -0,0 +1,348 @@
+package main
+
+import (
+  "os"
+  "sync"
+  "errors"
+  "time"
+  "context"
+  "testing"
+  "path/filepath"
+  "net/http/httptest"
+)
+
+func TestLedger(t *testing.T) {
+  path := filepath.Join(t.TempDir(), LEDGER_FILE)
+  l, err := OpenLedger(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if l.File("abc") != nil {
+    t.Fatal("new ledger has records")
+  }
+
+  if err := l.Begin("abc", "a.xlsx", "labor"); err != nil {
+    t.Fatal(err)
+  }
+
+  // 处理中途退出后重新打开，仍能识别该文件
+  l, err = OpenLedger(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  f := l.File("abc")
+  if f == nil || f.Name != "a.xlsx" || f.Profile != "labor" || f.State != FILE_PROCESSING {
+    t.Fatalf("File = %+v", f)
+  }
+
+  res := LedgerFile{ Name: "a.xlsx", State: FILE_PROCESSED, Submitted: 2, Results: []string{ "a.report.json" } }
+  if err := l.Finish("abc", res); err != nil {
+    t.Fatal(err)
+  }
+
+  l, err = OpenLedger(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  f = l.File("abc")
+  if f.State != FILE_PROCESSED || len(f.Results) != 1 || f.Submitted != 2 || f.FinishedAt.Before(f.StartedAt) {
+    t.Fatalf("File = %+v", f)
+  }
+
+  // 检查未通过（未开始提交）的文件可重新处理，提交过行的文件不可
+  tests := []struct {
+    res   LedgerFile
+    want  bool
+  }{
+    { LedgerFile{ State: FILE_PROCESSING }, true },
+    { LedgerFile{ State: FILE_PROCESSED }, true },
+    { LedgerFile{ State: FILE_FAILED, Submitted: 1 }, true },
+    { LedgerFile{ State: FILE_FAILED }, false },
+  }
+  for _, tt := range tests {
+    if got := tt.res.Blocks(); got != tt.want {
+      t.Errorf("Blocks(%+v) = %v, want %v", tt.res, got, tt.want)
+    }
+  }
+}
+
+// 多个进程共用处理记录时不互相覆盖，同时预留新建数也不超过每日上限
+func TestLedgerShared(t *testing.T) {
+  path := filepath.Join(t.TempDir(), LEDGER_FILE)
+  a, err := OpenLedger(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  b, err := OpenLedger(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  day := time.Now().Format(DAY_FORMAT)
+  limits := map[string]int{ "account": 25, "mediator:m1": 0 }
+  var mu sync.Mutex
+  var wg sync.WaitGroup
+  reserved := 0
+  for _, l := range []*Ledger{ a, b } {
+    for i := 0; i < 2; i++ {
+      wg.Add(1)
+      go func(l *Ledger) {
+        defer wg.Done()
+        for i := 0; i < 10; i++ {
+          ok, err := l.Reserve(day, limits)
+          if err != nil {
+            t.Error(err)
+          }
+          if ok {
+            mu.Lock()
+            reserved++
+            mu.Unlock()
+          }
+        }
+      }(l)
+    }
+  }
+  wg.Wait()
+
+  if reserved != 25 {
+    t.Errorf("reserved %d, want 25", reserved)
+  }
+
+  if err := a.Release(day, "account", "mediator:m1"); err != nil {
+    t.Fatal(err)
+  }
+
+  if err := a.Begin("abc", "a.xlsx", ""); err != nil {
+    t.Fatal(err)
+  }
+
+  if err := b.Finish("def", LedgerFile{ Name: "b.xlsx", State: FILE_FAILED }); err != nil {
+    t.Fatal(err)
+  }
+
+  // 读取时也能看到另一个实例写入的记录
+  if a.File("def") == nil || b.File("abc") == nil {
+    t.Fatal("records written by the other ledger are missing")
+  }
+
+  if n := b.Used(day, "account"); n != 24 {
+    t.Errorf("Used = %d, want 24", n)
+  }
+
+  l, err := OpenLedger(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if len(l.Files) != 2 || l.Quota[day]["account"] != 24 || l.Quota[day]["mediator:m1"] != 24 {
+    t.Errorf("ledger = %+v, %+v", l.Files, l.Quota)
+  }
+}
+
+// 多个进程同时认领同一文件时只有一个成功
+func TestLedgerClaim(t *testing.T) {
+  path := filepath.Join(t.TempDir(), LEDGER_FILE)
+  var ledgers []*Ledger
+  for i := 0; i < 2; i++ {
+    l, err := OpenLedger(path)
+    if err != nil {
+      t.Fatal(err)
+    }
+    ledgers = append(ledgers, l)
+  }
+
+  // 检查未通过的记录不妨碍认领
+  if err := ledgers[0].Reject("abc", LedgerFile{ Name: "a.xlsx", State: FILE_FAILED }); err != nil {
+    t.Fatal(err)
+  }
+
+  errs := make([]error, len(ledgers))
+  var wg sync.WaitGroup
+  for i, l := range ledgers {
+    wg.Add(1)
+    go func(i int, l *Ledger) {
+      defer wg.Done()
+      errs[i] = l.Begin("abc", "a.xlsx", "")
+    }(i, l)
+  }
+  wg.Wait()
+
+  claimed := 0
+  for _, err := range errs {
+    switch {
+    case err == nil:
+      claimed++
+    case !errors.Is(err, ErrFileClaimed):
+      t.Fatal(err)
+    }
+  }
+
+  if claimed != 1 {
+    t.Fatalf("%d ledgers claimed the file, want 1", claimed)
+  }
+
+  // 已认领的记录不被检查未通过的记录覆盖
+  if err := ledgers[1].Reject("abc", LedgerFile{ Name: "a.xlsx", State: FILE_FAILED }); err != nil {
+    t.Fatal(err)
+  }
+
+  if f := ledgers[0].File("abc"); f.State != FILE_PROCESSING {
+    t.Errorf("State = %s, want %s", f.State, FILE_PROCESSING)
+  }
+}
+
+func TestWatchProfile(t *testing.T) {
+  conf := validConf()
+  conf.Watch = &WatchConfig{ Interval: 10, Profiles: map[string]string{
+    "*.xlsx":       "general",
+    "labor-*.xlsx": "labor",
+  } }
+
+  w := NewWatcher(t.TempDir(), conf, nil, nil)
+  tests := map[string]string{
+    "a.xlsx":       "general",
+    "labor-1.xlsx": "labor",
+    "a.csv":        "",
+  }
+  for name, want := range tests {
+    if got := w.profileFor(name); got != want {
+      t.Errorf("profileFor(%q) = %q, want %q", name, got, want)
+    }
+  }
+
+  codes := codesOf(WatchCheck(conf))
+  if codes["PROFILE_UNKNOWN"] == "" {
+    t.Errorf("WatchCheck = %v, want PROFILE_UNKNOWN", codes)
+  }
+}
+
+// 在监视目录中放入文件
+func dropFile(t *testing.T, dir string, name string, src string) {
+  data, err := os.ReadFile(src)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+    t.Fatal(err)
+  }
+}
+
+func TestWatch(t *testing.T) {
+  fc := &fakeCourt{ response: `{"code":"1"}` }
+  srv := httptest.NewServer(fc)
+  defer srv.Close()
+
+  rows := [][]interface{}{
+    { "申请人", "被申请人", "申请人电话" },
+    { "张三", "李四", "13800001111" },
+    { "王五", "赵六", "13800002222" },
+  }
+  path := setupRun(t, srv.URL, rows)
+  if _, err := LoadConf(path); err != nil {
+    t.Fatal(err)
+  }
+  Conf.Request.Cookie = "JSESSIONID=abc"
+  Conf.Watch.Settle = 0
+
+  dir := t.TempDir()
+  book := Conf.Data.Path
+  dropFile(t, dir, "good.xlsx", book)
+  dropFile(t, dir, "~$good.xlsx", book)
+  dropFile(t, dir, "notes.txt", book)
+
+  csv := filepath.Join(t.TempDir(), "data.csv")
+  os.WriteFile(csv, []byte("\xEF\xBB\xBF申请人,被申请人,申请人电话\n孙七,周八,13800003333\n"), 0644)
+  dropFile(t, dir, "more.csv", csv)
+
+  // 缺少被申请人，检查未通过，不提交任何行
+  bad := writeBook(t, [][]interface{}{
+    { "申请人", "被申请人", "申请人电话", "被申请人全称" },
+    { "吴九", "郑十", "13800004444", "郑十" },
+    { "冯一", "", "13800005555", "陈二" },
+  })
+  dropFile(t, dir, "bad.xlsx", bad)
+
+  ledger, err := OpenLedger(Conf.LedgerPath())
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  control := NewControl(context.Background(), false)
+  defer control.Stop()
+
+  w := NewWatcher(dir, Conf, ledger, control)
+  for _, sub := range []string{ WATCH_PROCESSED, WATCH_FAILED, WATCH_RESULTS } {
+    os.MkdirAll(filepath.Join(dir, sub), 0755)
+  }
+
+  // 第一次扫描只记录文件大小，第二次扫描时处理
+  for i := 0; i < 2; i++ {
+    if err := w.Scan(); err != nil {
+      t.Fatal(err)
+    }
+  }
+
+  if len(fc.bodies) != 3 {
+    t.Fatalf("court received %d cases, want 3", len(fc.bodies))
+  }
+
+  exists := []string{
+    "processed/good.xlsx",
+    "processed/more.csv",
+    "failed/bad.xlsx",
+    "results/good.report.json",
+    "results/good.report.csv",
+    "results/good.result.xlsx",
+    "results/more.result.xlsx",
+    "results/bad.issues.json",
+    "~$good.xlsx",
+    "notes.txt",
+  }
+  for _, name := range exists {
+    if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+      t.Errorf("%s: %v", name, err)
+    }
+  }
+
+  if len(ledger.Files) != 3 {
+    t.Fatalf("ledger has %d files, want 3", len(ledger.Files))
+  }
+
+  // 同一文件再次放入时不再处理
+  dropFile(t, dir, "good-copy.xlsx", book)
+  for i := 0; i < 2; i++ {
+    if err := w.Scan(); err != nil {
+      t.Fatal(err)
+    }
+  }
+
+  if len(fc.bodies) != 3 {
+    t.Errorf("court received %d cases after duplicate, want 3", len(fc.bodies))
+  }
+
+  if _, err := os.Stat(filepath.Join(dir, "failed", "good-copy.xlsx")); err != nil {
+    t.Errorf("duplicate not moved to failed: %v", err)
+  }
+
+  // 修正配置后重新放入检查未通过的文件，此时应提交
+  Conf.Data.RespondentCol = "D"
+  dropFile(t, dir, "bad.xlsx", bad)
+  for i := 0; i < 2; i++ {
+    if err := w.Scan(); err != nil {
+      t.Fatal(err)
+    }
+  }
+
+  if len(fc.bodies) != 5 {
+    t.Errorf("court received %d cases after retry, want 5", len(fc.bodies))
+  }
+
+  if _, err := os.Stat(filepath.Join(dir, "processed", "bad.xlsx")); err != nil {
+    t.Errorf("retried file not processed: %v", err)
+  }
+}
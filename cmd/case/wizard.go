@@ -0,0 +1,302 @@
+package main
+
+import (
+  "io"
+  "os"
+  "fmt"
+  "time"
+  "bufio"
+  "reflect"
+  "strconv"
+  "strings"
+
+  "github.com/xuri/excelize/v2"
+)
+
+// 交互式向导不询问的配置项
+var wizardSkip = map[string]bool{
+  "version":                  true,
+  "case.startTime":           true,
+  "case.endTime":             true,
+  "data.mapper":              true,
+  "data.rows":                true,
+  "data.where":               true,
+  "request.cookie":           true,
+  "request.endpoint":         true,
+  "profiles":                 true,
+  "watch":                    true,
+  "schedule":                 true,
+  "serve":                    true,
+  "ledger":                   true,
+}
+
+// 交互式初始化向导
+type Wizard struct {
+  in          *bufio.Reader
+  out         io.Writer
+
+  // 数据源工作簿，用于选择工作表与列
+  book        *excelize.File
+
+  // 所选工作表的首行，用于提示各列内容
+  header      []string
+}
+
+func NewWizard(in io.Reader, out io.Writer) *Wizard {
+  return &Wizard{ in: bufio.NewReader(in), out: out }
+}
+
+// 逐项询问并填写配置，直接回车保留当前值
+func (w *Wizard) Run(conf *GlobalConfig) error {
+  fmt.Fprintln(w.out, T("wizard.intro"))
+  defer w.closeBook()
+
+  return w.walk(reflect.ValueOf(conf).Elem(), "")
+}
+
+func (w *Wizard) closeBook() {
+  if w.book != nil {
+    w.book.Close()
+    w.book = nil
+  }
+}
+
+func (w *Wizard) walk(v reflect.Value, prefix string) error {
+  t := v.Type()
+  for i := 0; i < t.NumField(); i++ {
+    f := t.Field(i)
+    name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+    if name == "" || name == "-" {
+      continue
+    }
+
+    path := name
+    if prefix != "" {
+      path = prefix + "." + name
+    }
+
+    // 姓名来自excel数据列
+    if wizardSkip[path] || strings.HasSuffix(path, ".name") {
+      continue
+    }
+
+    field := v.Field(i)
+    if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct {
+      if field.IsNil() {
+        field.Set(reflect.New(field.Type().Elem()))
+      }
+
+      title := FieldDoc(t, f.Name)
+      if title == "" {
+        title = FieldDoc(field.Type().Elem(), "")
+      }
+      fmt.Fprintf(w.out, "\n%s\n", T("wizard.section", title, path))
+
+      if err := w.walk(field.Elem(), path); err != nil {
+        return err
+      }
+      continue
+    }
+
+    if err := w.askField(field, path, FieldDoc(t, f.Name)); err != nil {
+      return err
+    }
+  }
+
+  return nil
+}
+
+// 询问单个配置项
+func (w *Wizard) askField(field reflect.Value, path string, doc string) error {
+  if doc == "" {
+    doc = path
+  }
+
+  current := fmt.Sprintf("%v", field.Interface())
+  if field.Kind() == reflect.Bool {
+    current = boolLabel(field.Bool())
+  }
+
+  key := path[strings.LastIndex(path, ".") + 1:]
+  choices := w.choicesFor(path, key)
+
+  for {
+    if len(choices) > 0 {
+      w.printChoices(choices)
+    }
+
+    fmt.Fprint(w.out, T("wizard.prompt", doc, path, current))
+    line, err := w.in.ReadString('\n')
+    if err != nil && (err != io.EOF || line == "") {
+      return NewError("err.input", err)
+    }
+
+    answer := strings.TrimSpace(line)
+    if answer == "" {
+      answer = current
+    }
+
+    value, err := w.check(field, path, key, answer, choices)
+    if err != nil {
+      fmt.Fprintf(w.out, "  %s\n", T("wizard.retry", err))
+      continue
+    }
+
+    if err := setField(field, value); err != nil {
+      fmt.Fprintf(w.out, "  %s\n", T("wizard.retry", err))
+      continue
+    }
+
+    if err := w.after(path, value); err != nil {
+      fmt.Fprintf(w.out, "  ! %v\n", err)
+    }
+    return nil
+  }
+}
+
+// 可选值：代码表、工作表名或列号
+func (w *Wizard) choicesFor(path string, key string) CodeTable {
+  if table, ok := fieldCodes[key]; ok {
+    return table
+  }
+
+  if path == "data.sheet" && w.book != nil {
+    var table CodeTable
+    for _, sheet := range w.book.GetSheetList() {
+      table = append(table, Code{ Value: sheet, Label: sheet })
+    }
+    return table
+  }
+
+  if strings.HasPrefix(path, "data.") && strings.HasSuffix(path, "Col") && len(w.header) > 0 {
+    var table CodeTable
+    for i, title := range w.header {
+      col, _ := excelize.ColumnNumberToName(i + 1)
+      table = append(table, Code{ Value: col, Label: title })
+    }
+    return table
+  }
+
+  return nil
+}
+
+func (w *Wizard) printChoices(choices CodeTable) {
+  for i, c := range choices {
+    if c.Value == c.Label {
+      fmt.Fprintf(w.out, "  %-12s", c.Value)
+    } else {
+      fmt.Fprintf(w.out, "  %s %-10s", c.Value, c.Label)
+    }
+
+    if (i + 1) % 6 == 0 || i == len(choices) - 1 {
+      fmt.Fprintln(w.out)
+    }
+  }
+}
+
+// 检查并规范化输入
+func (w *Wizard) check(field reflect.Value, path string, key string, answer string, choices CodeTable) (string, error) {
+  switch field.Kind() {
+  case reflect.Bool:
+    switch strings.ToLower(answer) {
+    case "y", "yes", "true", "是":
+      return "true", nil
+    case "n", "no", "false", "否":
+      return "false", nil
+    }
+    return "", NewError("err.inputBool")
+  case reflect.Int:
+    n, err := strconv.Atoi(answer)
+    if err != nil || n < 0 {
+      return "", NewError("err.inputCount")
+    }
+    return answer, nil
+  }
+
+  if len(choices) > 0 && answer != "" {
+    value, ok := choices.Lookup(answer)
+    if !ok && strings.HasSuffix(path, "Col") {
+      value, ok = strings.ToUpper(answer), true
+    }
+
+    if !ok {
+      return "", NewError("err.inputChoice", answer)
+    }
+    answer = value
+  }
+
+  switch {
+  case answer == "":
+    return answer, nil
+  case key == "tel":
+    return CourtLocale.NormalizeMobile(answer)
+  case key == "staticPhone":
+    return CourtLocale.NormalizeLandline(answer)
+  case key == "birthday":
+    if _, err := time.Parse("2006-01-02", answer); err != nil {
+      return "", NewError("err.inputDate")
+    }
+  case key == "year":
+    if n, err := strconv.Atoi(answer); err != nil || n < 2000 || n > 2100 {
+      return "", NewError("err.inputYear")
+    }
+  case strings.HasSuffix(key, "Col"):
+    if _, err := excelize.ColumnNameToNumber(answer); err != nil {
+      return "", NewError("err.inputCol")
+    }
+    return strings.ToUpper(answer), nil
+  case key == "cookieFile" && answer != "-":
+    if _, err := os.Stat(answer); err != nil {
+      fmt.Fprintf(w.out, "  ! %s\n", T("wizard.cookieMissing", answer))
+    }
+  }
+
+  return answer, nil
+}
+
+// 填写数据表路径与工作表后，读取工作簿以提示后续选项
+func (w *Wizard) after(path string, value string) error {
+  switch path {
+  case "data.path":
+    w.closeBook()
+    w.header = nil
+    if value == "" {
+      return nil
+    }
+
+    book, err := excelize.OpenFile(value)
+    if err != nil {
+      return NewError("err.wizardBook", err)
+    }
+    w.book = book
+  case "data.sheet":
+    w.header = nil
+    if w.book == nil || value == "" {
+      return nil
+    }
+
+    rows, err := w.book.Rows(value)
+    if err != nil {
+      return NewError("err.wizardSheet", err)
+    }
+    defer rows.Close()
+
+    if rows.Next() {
+      header, err := rows.Columns()
+      if err != nil {
+        return err
+      }
+      w.header = header
+    }
+  }
+
+  return nil
+}
+
+func boolLabel(b bool) string {
+  if b {
+    return T("wizard.yes")
+  }
+
+  return T("wizard.no")
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+  "strings"
+  "testing"
+)
+
+func TestWizardLang(t *testing.T) {
+  lang := CurrentLang
+  CurrentLang = LANG_EN
+  defer func() { CurrentLang = lang }()
+
+  // 案件年份先输入无效值，其余均保留当前值
+  conf := InitConf()
+  in := "\nabc\n" + strings.Repeat("\n", 200)
+  var out strings.Builder
+  if err := NewWizard(strings.NewReader(in), &out).Run(conf); err != nil {
+    t.Fatal(err)
+  }
+
+  text := out.String()
+  for _, want := range []string{ "Fill in each setting", "(case) ==", "invalid year, please try again" } {
+    if !strings.Contains(text, want) {
+      t.Errorf("output lacks %q", want)
+    }
+  }
+
+  if !strings.Contains(text, "[yes]: ") && !strings.Contains(text, "[no]: ") {
+    t.Error("output lacks yes/no defaults")
+  }
+
+  if strings.Contains(text, "请重新输入") || strings.Contains(text, "]：") {
+    t.Errorf("output has untranslated prompts:\n%s", text)
+  }
+}
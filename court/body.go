@@ -0,0 +1,129 @@
+package court
+
+// 申请人请求体
+type ApplicantBody struct {
+  Type string             `json:"applicantType"`
+  Name string             `json:"applicantName"`
+  Tel string              `json:"applicantTel"`
+  CredentialsType string  `json:"credentialsType"`
+  CredentialsName string  `json:"credentialsName"`
+  IDCardNo string         `json:"applicantIDCardNo"`
+  Sex string              `json:"applicantSex"`
+  Birthday string         `json:"applicantBirthday"`
+  Nation string           `json:"applicantNation"`
+  AreaCode string         `json:"areaCode"`
+  Address string          `json:"applicantAddress"`
+  Email string            `json:"email"`
+  AgentList []string      `json:"agentList"`
+  FileList []string       `json:"fileList"`
+}
+
+// 被申请人请求体
+type RespondentBody struct {
+  Type string             `json:"respondentType"`
+  Name string             `json:"respondentName"`
+  Tel string              `json:"respondentTel"`
+  StaticPhone string      `json:"respondentStaticPhone"`
+  CredentialsType string  `json:"credentialsType"`
+  CredentialsName string  `json:"credentialsName"`
+  IDCardNo string         `json:"respondentIDCardNo"`
+  Sex string              `json:"respondentSex"`
+  Birthday string         `json:"respondentBirthday"`
+  Nation string           `json:"respondentNation"`
+  AreaCode string         `json:"areaCode"`
+  Address string          `json:"respondentAddress"`
+  Email string            `json:"email"`
+  AgentList []string      `json:"agentList"`
+  FileList []string       `json:"fileList"`
+}
+
+// 新建案例请求体
+type CaseBody struct {
+  Type string             `json:"type"`
+  Year string             `json:"year"`
+  DraftFlag string        `json:"draftFlag"`
+  CaseCatalog string      `json:"caseCatalog"`
+  DisputeType string      `json:"disputeType"`
+  CauseCode string        `json:"causeCode"`
+  MediationCaseNo string  `json:"mediationCaseNo"`
+  Money string            `json:"money"`
+  ClaimMoney string       `json:"claimMoney"`
+  State string            `json:"state"`
+  SuccessState string     `json:"successState"`
+  Remark string           `json:"remark"`
+  StartTime string        `json:"startTimeStr"`
+  EndTime string          `json:"endTimeStr"`
+  Dispute string          `json:"dispute"`
+  Agreement string        `json:"agreement"`
+  MediatorId string       `json:"mediatorId"`
+  AutoCreate string       `json:"autoCreate"`
+  DocList []string        `json:"docList"`
+  NoteList []string       `json:"noteList"`
+
+  ApplicantList  []*ApplicantBody  `json:"applicantPartyList"`
+  RespondentList []*RespondentBody `json:"respondentPartyList"`
+
+  Evidences []string      `json:"evidences"`
+}
+
+// 由已填入该行数据的案件配置生成请求体，不修改配置也不依赖全局状态
+func BuildCaseBody(ca *CaseConfig) *CaseBody {
+  body := &CaseBody{
+    Type:             ca.Type,
+    Year:             ca.Year,
+    DraftFlag:        "1",
+    CaseCatalog:      ca.CaseCatalog,
+    DisputeType:      ca.DisputeType,
+    CauseCode:        ca.CauseCode,
+    State:            ca.State,
+    SuccessState:     ca.SuccessState,
+    StartTime:        ca.StartTime,
+    EndTime:          ca.EndTime,
+    Dispute:          ca.Dispute,
+    Agreement:        ca.Agreement,
+    MediatorId:       ca.DefaultMediatorId,
+    AutoCreate:       ca.AutoCreate,
+    DocList:          []string{},
+    NoteList:         []string{},
+    ApplicantList:    []*ApplicantBody{},
+    RespondentList:   []*RespondentBody{},
+    Evidences:        []string{},
+  }
+
+  if app := ca.DefaultApplicant; app != nil {
+    body.ApplicantList = append(body.ApplicantList, &ApplicantBody{
+      Type:             app.Type,
+      Name:             app.Name,
+      Tel:              app.Tel,
+      CredentialsType:  app.CredentialsType,
+      IDCardNo:         app.IDCardNo,
+      Sex:              app.Sex,
+      Birthday:         app.Birthday,
+      Nation:           app.Nation,
+      AreaCode:         app.AreaCode,
+      Address:          app.Address,
+      AgentList:        []string{},
+      FileList:         []string{},
+    })
+  }
+
+  if res := ca.DefaultRespondent; res != nil {
+    body.RespondentList = append(body.RespondentList, &RespondentBody{
+      Type:             res.Type,
+      Name:             res.Name,
+      Tel:              res.Tel,
+      StaticPhone:      res.StaticPhone,
+      CredentialsType:  res.CredentialsType,
+      IDCardNo:         res.IDCardNo,
+      Sex:              res.Sex,
+      Birthday:         res.Birthday,
+      Nation:           res.Nation,
+      AreaCode:         res.AreaCode,
+      Address:          res.Address,
+      AgentList:        []string{},
+      FileList:         []string{},
+    })
+  }
+
+  return body
+}
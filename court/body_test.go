@@ -0,0 +1,109 @@
+package court
+
+import (
+  "testing"
+)
+
+func testCase() *CaseConfig {
+  return &CaseConfig{
+    Type:               "0",
+    Year:               "2026",
+    CaseCatalog:        "1",
+    DisputeType:        "2",
+    CauseCode:          "3",
+    State:              "4",
+    SuccessState:       "1",
+    StartTime:          "2026-10-01 09:00:00",
+    EndTime:            "2026-10-05 09:00:00",
+    Dispute:            "纠纷概况",
+    Agreement:          "调解方案",
+    AutoCreate:         "1",
+    DefaultMediatorId:  "m1",
+    DefaultApplicant:   &PersonConfig{
+      Type: "1", Name: "张三", Tel: "13800000000", CredentialsType: "1",
+      IDCardNo: "110101199001011234", Sex: "1", Birthday: "1990-01-01",
+      Nation: "01", AreaCode: "110101", Address: "北京市东城区",
+    },
+    DefaultRespondent:  &PersonConfig{
+      Type: "1", Name: "李四", Tel: "13900000000", StaticPhone: "010-12345678",
+      CredentialsType: "1", Sex: "2", Birthday: "1991-02-02",
+      Nation: "03", AreaCode: "110102", Address: "北京市西城区",
+    },
+  }
+}
+
+func TestBuildCaseBody(t *testing.T) {
+  body := BuildCaseBody(testCase())
+
+  tests := []struct {
+    name string
+    got  string
+    want string
+  }{
+    { "type", body.Type, "0" },
+    { "year", body.Year, "2026" },
+    { "draftFlag", body.DraftFlag, "1" },
+    { "causeCode", body.CauseCode, "3" },
+    { "startTime", body.StartTime, "2026-10-01 09:00:00" },
+    { "endTime", body.EndTime, "2026-10-05 09:00:00" },
+    { "mediatorId", body.MediatorId, "m1" },
+    { "applicant.name", body.ApplicantList[0].Name, "张三" },
+    { "applicant.idCardNo", body.ApplicantList[0].IDCardNo, "110101199001011234" },
+    { "applicant.nation", body.ApplicantList[0].Nation, "01" },
+    { "applicant.address", body.ApplicantList[0].Address, "北京市东城区" },
+    { "respondent.name", body.RespondentList[0].Name, "李四" },
+    { "respondent.staticPhone", body.RespondentList[0].StaticPhone, "010-12345678" },
+    { "respondent.nation", body.RespondentList[0].Nation, "03" },
+    { "respondent.sex", body.RespondentList[0].Sex, "2" },
+  }
+
+  for _, tt := range tests {
+    if tt.got != tt.want {
+      t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+    }
+  }
+}
+
+func TestBuildCaseBodyIsPure(t *testing.T) {
+  ca := testCase()
+  a := BuildCaseBody(ca)
+
+  ca.DefaultApplicant.Name = "王五"
+  b := BuildCaseBody(ca)
+
+  if a.ApplicantList[0].Name != "张三" || b.ApplicantList[0].Name != "王五" {
+    t.Errorf("bodies share state: %q, %q", a.ApplicantList[0].Name, b.ApplicantList[0].Name)
+  }
+
+  if a.ApplicantList[0] == b.ApplicantList[0] {
+    t.Error("bodies share the same applicant")
+  }
+}
+
+func TestBuildCaseBodyWithoutParties(t *testing.T) {
+  ca := testCase()
+  ca.DefaultApplicant = nil
+  ca.DefaultRespondent = nil
+
+  body := BuildCaseBody(ca)
+  if len(body.ApplicantList) != 0 || len(body.RespondentList) != 0 {
+    t.Errorf("got %d applicants, %d respondents, want none",
+             len(body.ApplicantList), len(body.RespondentList))
+  }
+
+  // 空列表须序列化为[]而非null
+  if body.DocList == nil || body.NoteList == nil || body.Evidences == nil {
+    t.Error("lists must not be nil")
+  }
+}
+
+func TestCopy(t *testing.T) {
+  ca := testCase()
+  c := ca.Copy()
+  c.DefaultApplicant.Name = "王五"
+  c.DefaultRespondent.Tel = "13700000000"
+
+  if ca.DefaultApplicant.Name != "张三" || ca.DefaultRespondent.Tel != "13900000000" {
+    t.Error("Copy shares parties with the original")
+  }
+}
@@ -0,0 +1,245 @@
+package court
+
+import (
+  "io"
+  "time"
+  "errors"
+  "context"
+  "strings"
+  "log/slog"
+  "net/url"
+  "net/http"
+  "encoding/json"
+)
+
+// 新建案例接口
+const ENDPOINT string = "http://tiaojie.court.gov.cn/fayuan/a/offline/addOffline"
+
+// 会话已失效（接口返回登录页），需更新cookie后重试；Client返回按其Locale翻译的同编号错误，用errors.Is判断
+var ErrSessionExpired = &Error{ Key: "err.sessionExpired" }
+
+// 无法读取响应，案例可能已经新建，不可重试
+var ErrUnknownResult = &Error{ Key: "err.unknownResult" }
+
+// 每次请求时取得cookie
+type CookieSource func() (string, error)
+
+// 固定的cookie
+func StaticCookie(cookie string) CookieSource {
+  return func() (string, error) {
+    return cookie, nil
+  }
+}
+
+// 伪请求模式下打印请求内容时用于遮盖个人信息
+type Masker interface {
+  Body(body *CaseBody) *CaseBody
+  Cookie(cookie string) string
+}
+
+// 调解平台客户端
+type Client struct {
+  // 新建案例接口
+  Endpoint    string
+
+  HTTP        *http.Client
+  Cookie      CookieSource
+  Logger      *slog.Logger
+
+  // 失败重试次数与每次请求后的等待时间
+  Retry       int
+  Delay       time.Duration
+
+  // 伪请求模式，只打印请求内容
+  Fake        bool
+
+  // 伪请求模式下的遮盖策略，为空时原样打印
+  Mask        Masker
+
+  // 日志与错误的消息语言，为空时使用默认消息
+  Locale      *Locale
+}
+
+func NewClient(cookie CookieSource, timeout time.Duration) *Client {
+  return &Client{
+    Endpoint: ENDPOINT,
+    HTTP:     &http.Client{ Timeout: timeout },
+    Cookie:   cookie,
+    Logger:   slog.Default(),
+  }
+}
+
+// 使用指定日志（如带有数据行上下文）的客户端副本
+func (c *Client) WithLogger(lg *slog.Logger) *Client {
+  cc := *c
+  cc.Logger = lg
+  return &cc
+}
+
+// 按接口地址生成请求
+func (c *Client) newRequest(ctx context.Context, body *CaseBody) (*http.Request, []byte, error) {
+  s, err := json.Marshal(body)
+  if err != nil {
+    c.Logger.Debug(c.Locale.text("log.marshal"), "err", err)
+    return nil, nil, err
+  }
+
+  cookie, err := c.Cookie()
+  if err != nil {
+    return nil, nil, err
+  }
+
+  // 设置表单数据
+  form := url.Values{
+    "mediationFormStr": { string(s) },
+  }
+
+  request, err := http.NewRequestWithContext(ctx, "POST", c.Endpoint, strings.NewReader(form.Encode()))
+  if err != nil {
+    c.Logger.Debug(c.Locale.text("log.newRequest"), "err", err)
+    return nil, nil, err
+  }
+
+  origin := request.URL.Scheme + "://" + request.URL.Host
+
+  // 设置请求头
+  request.Header.Add("Accept", "application/json, text/javascript, */*; q=0.01")
+  request.Header.Add("Accept-Encoding", "gzip, deflate, br")
+  request.Header.Add("Connection", "keep-alive")
+  request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+  request.Header.Add("Cookie", cookie)
+  request.Header.Add("Host", request.URL.Host)
+  request.Header.Add("Origin", origin)
+  request.Header.Add("Referer", origin + "/fayuan/offline/toAddOffline")
+  request.Header.Add("X-Requested-With", "XMLHttpRequest")
+  request.Header.Add("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:109.0) Gecko/20100101 Firefox/114.0")
+
+  return request, s, nil
+}
+
+// 发送一次新建请求；ctx取消不会中断已发出的请求，以免新建结果未知
+func (c *Client) Submit(ctx context.Context, body *CaseBody) error {
+  if body == nil || c.Cookie == nil {
+    return c.Locale.error("err.requestArgs")
+  }
+
+  request, s, err := c.newRequest(context.WithoutCancel(ctx), body)
+  if err != nil {
+    return err
+  }
+
+  // 若为伪请求模式，打印所有相关信息
+  if c.Fake {
+    return c.dump(request, body, s)
+  }
+
+  response, err := c.HTTP.Do(request)
+  if err != nil {
+    c.Logger.Debug(c.Locale.text("log.send"), "err", err)
+    return err
+  }
+
+  defer response.Body.Close()
+
+  // 判断返回体
+  if response.StatusCode != 200 {
+    return c.Locale.error("err.status", response.StatusCode)
+  }
+
+  bytes, err := io.ReadAll(response.Body)
+  if err != nil {
+    c.Logger.Error(c.Locale.text("log.unknownResult"), "err", err)
+    return c.Locale.error(ErrUnknownResult.Key)
+  }
+
+  rbody := string(bytes)
+  if strings.Contains(rbody, "html") {
+    c.Logger.Error(c.Locale.text("log.sessionExpired"))
+    return c.Locale.error(ErrSessionExpired.Key)
+  }
+
+  if strings.Contains(rbody, "-1") {
+    return c.Locale.error("err.rejected", rbody)
+  }
+
+  c.Logger.Info(c.Locale.text("log.created"), "response", rbody)
+  return nil
+}
+
+func (c *Client) dump(request *http.Request, body *CaseBody, s []byte) error {
+  c.Logger.Info(c.Locale.text("log.fake"), "method", request.Method, "endpoint", c.Endpoint)
+
+  for name, values := range request.Header {
+    for _, value := range values {
+      if name == "Cookie" && c.Mask != nil {
+        value = c.Mask.Cookie(value)
+      }
+      c.Logger.Info(c.Locale.text("log.header"), "name", name, "value", value)
+    }
+  }
+
+  if c.Mask != nil {
+    body = c.Mask.Body(body)
+    s, _ = json.Marshal(body)
+  }
+
+  xx, err := json.MarshalIndent(body, "", "  ")
+  if err != nil {
+    c.Logger.Debug(c.Locale.text("log.marshal"), "err", err)
+    return err
+  }
+
+  c.Logger.Info(c.Locale.text("log.body"), "body", string(xx))
+  c.Logger.Info(c.Locale.text("log.form"), "form", string(s))
+  return nil
+}
+
+// 发送新建请求，失败时按Retry重试；会话失效或结果未知时不重试
+func (c *Client) SubmitWithRetry(ctx context.Context, body *CaseBody) error {
+  err := c.WithLogger(c.Logger.With("attempt", 1)).Submit(ctx, body)
+  if err != nil && retryable(err) {
+    c.Logger.Warn(c.Locale.text("log.retry"), "attempt", 1, "retry", c.Retry, "err", err)
+
+    for i := 0; i < c.Retry && err != nil && retryable(err); i++ {
+      attempt := c.Logger.With("attempt", i + 2)
+      if !c.sleep(ctx) {
+        return err
+      }
+      attempt.Debug(c.Locale.text("log.retryWait"), "delay", c.Delay)
+
+      err = c.WithLogger(attempt).Submit(ctx, body)
+      if err != nil {
+        attempt.Warn(c.Locale.text("log.retryFailed"), "err", err)
+      }
+    }
+  }
+
+  if err != nil {
+    return err
+  }
+
+  c.sleep(ctx)
+  c.Logger.Debug(c.Locale.text("log.rest"), "delay", c.Delay)
+  return nil
+}
+
+// 等待Delay，ctx取消时提前返回false
+func (c *Client) sleep(ctx context.Context) bool {
+  if ctx.Err() != nil {
+    return false
+  }
+
+  t := time.NewTimer(c.Delay)
+  defer t.Stop()
+
+  select {
+  case <-t.C:
+    return true
+  case <-ctx.Done():
+    return false
+  }
+}
+
+func retryable(err error) bool {
+  return !errors.Is(err, ErrSessionExpired) && !errors.Is(err, ErrUnknownResult)
+}
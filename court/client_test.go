@@ -0,0 +1,136 @@
+package court
+
+import (
+  "io"
+  "time"
+  "errors"
+  "context"
+  "testing"
+  "log/slog"
+  "net/http"
+  "encoding/json"
+  "net/http/httptest"
+)
+
+// 模拟新建案例接口，按顺序返回响应
+func mockServer(t *testing.T, status int, responses ...string) (*httptest.Server, *[]*CaseBody) {
+  var bodies []*CaseBody
+  srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    if got := r.Header.Get("Cookie"); got != "JSESSIONID=abc" {
+      t.Errorf("Cookie = %q", got)
+    }
+
+    body := &CaseBody{}
+    if err := json.Unmarshal([]byte(r.PostFormValue("mediationFormStr")), body); err != nil {
+      t.Errorf("mediationFormStr: %v", err)
+    }
+    bodies = append(bodies, body)
+
+    res := responses[len(responses) - 1]
+    if len(bodies) <= len(responses) {
+      res = responses[len(bodies) - 1]
+    }
+
+    w.WriteHeader(status)
+    io.WriteString(w, res)
+  }))
+
+  t.Cleanup(srv.Close)
+  return srv, &bodies
+}
+
+func testClient(endpoint string) *Client {
+  c := NewClient(StaticCookie("JSESSIONID=abc"), time.Second)
+  c.Endpoint = endpoint
+  c.Retry = 2
+  c.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+  return c
+}
+
+func TestSubmitWithRetry(t *testing.T) {
+  tests := []struct {
+    name      string
+    status    int
+    responses []string
+    wantErr   error
+    anyErr    bool
+    requests  int
+  }{
+    { "success", 200, []string{ `{"code":"1"}` }, nil, false, 1 },
+    { "success after retry", 200, []string{ `{"code":"-1"}`, `{"code":"1"}` }, nil, false, 2 },
+    { "rejected", 200, []string{ `{"code":"-1"}` }, nil, true, 3 },
+    { "server error", 500, []string{ "" }, nil, true, 3 },
+    { "session expired", 200, []string{ "<html>login</html>" }, ErrSessionExpired, true, 1 },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      srv, bodies := mockServer(t, tt.status, tt.responses...)
+      err := testClient(srv.URL).SubmitWithRetry(context.Background(), BuildCaseBody(testCase()))
+
+      if tt.anyErr != (err != nil) {
+        t.Fatalf("err = %v, want error: %v", err, tt.anyErr)
+      }
+
+      if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+        t.Errorf("err = %v, want %v", err, tt.wantErr)
+      }
+
+      if len(*bodies) != tt.requests {
+        t.Errorf("requests = %d, want %d", len(*bodies), tt.requests)
+      }
+
+      if len(*bodies) > 0 && (*bodies)[0].ApplicantList[0].Name != "张三" {
+        t.Errorf("applicant = %q", (*bodies)[0].ApplicantList[0].Name)
+      }
+    })
+  }
+}
+
+func TestSubmitFake(t *testing.T) {
+  srv, bodies := mockServer(t, 200, `{"code":"1"}`)
+  c := testClient(srv.URL)
+  c.Fake = true
+
+  if err := c.Submit(context.Background(), BuildCaseBody(testCase())); err != nil {
+    t.Fatal(err)
+  }
+
+  if len(*bodies) != 0 {
+    t.Errorf("fake mode sent %d requests", len(*bodies))
+  }
+}
+
+func TestErrorTranslate(t *testing.T) {
+  srv, _ := mockServer(t, 200, "<html>login</html>")
+  c := testClient(srv.URL)
+  c.Locale = &Locale{ Translate: func(key string, args ...interface{}) string {
+    return "translated:" + key
+  } }
+
+  err := c.Submit(context.Background(), BuildCaseBody(testCase()))
+  if !errors.Is(err, ErrSessionExpired) || err.Error() != "translated:err.sessionExpired" {
+    t.Errorf("err = %v", err)
+  }
+
+  // 默认消息不受其他客户端的Locale影响
+  if got := ErrSessionExpired.Error(); got != "会话已失效，请更新Cookie信息" {
+    t.Errorf("Error() = %q", got)
+  }
+}
+
+func TestSubmitCanceled(t *testing.T) {
+  srv, bodies := mockServer(t, 200, `{"code":"-1"}`)
+  ctx, cancel := context.WithCancel(context.Background())
+  cancel()
+
+  // 已取消时仍发出首次请求，但不再等待重试
+  err := testClient(srv.URL).SubmitWithRetry(ctx, BuildCaseBody(testCase()))
+  if err == nil || err == context.Canceled {
+    t.Errorf("err = %v, want the rejection", err)
+  }
+
+  if len(*bodies) != 1 {
+    t.Errorf("requests = %d, want 1", len(*bodies))
+  }
+}
@@ -0,0 +1,101 @@
+package court
+
+// 申请人或被申请人设置
+type PersonConfig struct {
+  // 当事人类型
+  Type                string          `json:"type"`
+
+  // 姓名
+  Name                string          `json:"name"`
+
+  // 手机号码
+  Tel                 string          `json:"tel"`
+
+  // 固定电话
+  StaticPhone         string          `json:"staticPhone"`
+
+  // 证件类型
+  CredentialsType     string          `json:"credentialsType"`
+
+  // 证件号码
+  IDCardNo            string          `json:"idCardNo"`
+
+  // 性别
+  Sex                 string          `json:"sex"`
+
+  // 出生日期
+  Birthday            string          `json:"birthday"`
+
+  // 民族
+  Nation              string          `json:"nation"`
+
+  // 居住地代码
+  AreaCode            string          `json:"areaCode"`
+
+  // 居住地址
+  Address             string          `json:"address"`
+}
+
+// 案件设置
+type CaseConfig struct {
+  // 调解类型
+  Type                string          `json:"type"`
+
+  // 案件年份
+  Year                string          `json:"year"`
+
+  // 案件类型
+  CaseCatalog         string          `json:"caseCatalog"`
+
+  // 纠纷类型
+  DisputeType         string          `json:"disputeType"`
+
+  // 案由
+  CauseCode           string          `json:"causeCode"`
+
+  // 案件状态
+  State               string          `json:"state"`
+
+  // 成功状态
+  SuccessState        string          `json:"successState"`
+
+  // 调解开始日期（已弃用，总会被随机日期覆写）
+  StartTime           string          `json:"startTime,omitempty"`
+
+  // 调解结束日期（已弃用，总会被随机日期覆写）
+  EndTime             string          `json:"endTime,omitempty"`
+
+  // 纠纷概况
+  Dispute             string          `json:"dispute"`
+
+  // 调解方案
+  Agreement           string          `json:"agreement"`
+
+  // 自动生成调解协议
+  AutoCreate          string          `json:"autoCreate"`
+
+  // 默认申请人信息
+  DefaultApplicant    *PersonConfig   `json:"defaultApplicant"`
+
+  // 默认被申请人信息
+  DefaultRespondent   *PersonConfig   `json:"defaultRespondent"`
+
+  // 默认调解员ID
+  DefaultMediatorId   string          `json:"defaultMediatorId"`
+}
+
+// 复制案件配置（含当事人信息），以免逐行填充时污染默认配置
+func (ca *CaseConfig) Copy() *CaseConfig {
+  c := *ca
+  if ca.DefaultApplicant != nil {
+    app := *ca.DefaultApplicant
+    c.DefaultApplicant = &app
+  }
+
+  if ca.DefaultRespondent != nil {
+    res := *ca.DefaultRespondent
+    c.DefaultRespondent = &res
+  }
+
+  return &c
+}
@@ -0,0 +1,127 @@
+package court
+
+import (
+  "fmt"
+)
+
+// 翻译消息，调用方可使用自己的消息目录（编号同下）
+type Translator func(key string, args ...interface{}) string
+
+// 默认消息
+func DefaultTranslate(key string, args ...interface{}) string {
+  s, ok := messages[key]
+  if !ok {
+    s = key
+  }
+
+  if len(args) > 0 {
+    return fmt.Sprintf(s, args...)
+  }
+
+  return s
+}
+
+// 默认消息
+var messages = map[string]string{
+  "err.requestArgs":      "新建请求参数错误",
+  "err.status":           "新建失败，返回值：%d",
+  "err.rejected":         "新建失败，返回码为-1：%s",
+  "err.sessionExpired":   "会话已失效，请更新Cookie信息",
+  "err.unknownResult":    "新建结果未知，请手动确认",
+  "log.marshal":          "无法序列化请求体",
+  "log.newRequest":       "无法创建请求",
+  "log.fake":             "伪请求",
+  "log.header":           "请求头",
+  "log.body":             "请求体",
+  "log.form":             "请求体（表单格式）",
+  "log.send":             "无法发送请求",
+  "log.unknownResult":    "新建结果未知，无法解析响应请求体，请手动确认",
+  "log.sessionExpired":   "新建失败，请立即更新Cookie信息",
+  "log.created":          "新建成功！",
+  "log.retry":            "首次请求失败，即将重试",
+  "log.retryWait":        "已等待，再次尝试",
+  "log.retryFailed":      "重试失败",
+  "log.rest":             "休息一下...",
+
+  // 当事人信息与数据行
+  "party.applicant":              "申请人",
+  "party.respondent":             "被申请人",
+  "party.either":                 "申请人/被申请人",
+  "source.cell":                  "单元格%s",
+  "source.config":                "配置项%s",
+  "err.mobile":                   "手机号格式错误（应为11位，以1开头）：%q",
+  "err.landline":                 "固定电话格式错误（应包含区号，如010-12345678）：%q",
+  "err.mapperKey":                "自定义配置键格式错误：%s",
+  "err.mapperParty":              "自定义配置键的当事人未知：%s",
+  "err.mapperField":              "自定义配置键的字段未知：%s",
+  "err.defaultParty":             "默认%s为空",
+  "err.column":                   "无法获取%s列名对应的索引：%v",
+  "err.columnName":               "列名应为A至XFD",
+  "PARTY_EMPTY":                  "%s信息为空",
+  "PARTY_TYPE_EMPTY":             "当事人类型为空",
+  "PARTY_NAME_EMPTY":             "当事人姓名为空",
+  "PARTY_TEL_EMPTY":              "当事人手机号为空",
+  "PARTY_TEL_INVALID":            "%s手机号无法识别（%s）：%v",
+  "PARTY_STATIC_PHONE_INVALID":   "%s固定电话无法识别（%s）：%v",
+  "PARTY_CREDENTIALS_TYPE_EMPTY": "当事人证件类型为空",
+  "PARTY_ID_CARD_EMPTY":          "当事人身份证号为空（个别情况下允许）",
+  "PARTY_SEX_EMPTY":              "当事人性别为空",
+  "PARTY_BIRTHDAY_EMPTY":         "当事人生日为空",
+  "PARTY_NATION_EMPTY":           "当事人民族为空",
+  "PARTY_AREA_CODE_EMPTY":        "当事人地区代号为空",
+  "PARTY_ADDRESS_EMPTY":          "当事人地址为空",
+}
+
+// 翻译消息，未指定时使用默认消息
+func (tr Translator) text(key string, args ...interface{}) string {
+  if tr == nil {
+    return DefaultTranslate(key, args...)
+  }
+  return tr(key, args...)
+}
+
+// 消息语言与电话号码遮盖，供Client与当事人检查使用；为nil或字段为空时使用默认消息、原样输出电话号码
+type Locale struct {
+  Translate   Translator
+  MaskPhone   func(phone string) string
+}
+
+func (lc *Locale) translator() Translator {
+  if lc == nil {
+    return nil
+  }
+  return lc.Translate
+}
+
+func (lc *Locale) text(key string, args ...interface{}) string {
+  return lc.translator().text(key, args...)
+}
+
+func (lc *Locale) error(key string, args ...interface{}) *Error {
+  return &Error{ Key: key, Args: args, tr: lc.translator() }
+}
+
+func (lc *Locale) mask(phone string) string {
+  if lc == nil || lc.MaskPhone == nil {
+    return phone
+  }
+  return lc.MaskPhone(phone)
+}
+
+// 可翻译的错误，消息在输出时才按生成该错误的Locale翻译
+type Error struct {
+  Key         string
+  Args        []interface{}
+
+  tr          Translator
+}
+
+func (e *Error) Error() string {
+  return e.tr.text(e.Key, e.Args...)
+}
+
+// 编号相同即为同一错误，如 errors.Is(err, ErrSessionExpired)
+func (e *Error) Is(target error) bool {
+  t, ok := target.(*Error)
+  return ok && t.Key == e.Key
+}
@@ -0,0 +1,239 @@
+package court
+
+import (
+  "regexp"
+  "strings"
+)
+
+var (
+  mobilePattern     = regexp.MustCompile(`^1[3-9]\d{9}$`)
+  landlinePattern   = regexp.MustCompile(`^(0\d{2,3})-?(\d{7,8})(?:(?:-|转|#)(\d{1,6}))?$`)
+  phoneStripper     = strings.NewReplacer(" ", "", "　", "", "\t", "", "-", "", "－", "")
+  landlineStripper  = strings.NewReplacer(" ", "", "　", "", "\t", "",
+                                          "(", "", ")", "-", "（", "", "）", "-", "－", "-")
+)
+
+// 当事人信息的问题，Code为问题编号（同消息编号），Args为消息参数
+type Problem struct {
+  // 字段路径，如 applicant.tel、case.defaultApplicant.name
+  Path        string
+
+  // 单元格，如 C17（不是来自数据行时为空）
+  Cell        string
+
+  // 警告（个别情况下允许），否则为错误
+  Warning     bool
+
+  Code        string
+  Args        []interface{}
+
+  tr          Translator
+}
+
+func (p *Problem) Error() string {
+  return p.tr.text(p.Code, p.Args...)
+}
+
+func (lc *Locale) problem(path string, cell string, code string, args ...interface{}) *Problem {
+  return &Problem{ Path: path, Cell: cell, Code: code, Args: args, tr: lc.translator() }
+}
+
+// 是否有错误级别的问题
+func HasError(problems []*Problem) bool {
+  for _, p := range problems {
+    if !p.Warning {
+      return true
+    }
+  }
+
+  return false
+}
+
+// 去除国际区号（+86、0086、86）
+func trimCountryCode(s string) (string, bool) {
+  for _, prefix := range []string{ "+86", "0086" } {
+    if strings.HasPrefix(s, prefix) {
+      return strings.TrimPrefix(strings.TrimPrefix(s, prefix), "-"), true
+    }
+  }
+
+  return s, false
+}
+
+// 规范化手机号：去除空格与横线、国际区号，并检查11位号码格式
+func (lc *Locale) NormalizeMobile(tel string) (string, error) {
+  s := phoneStripper.Replace(strings.TrimSpace(tel))
+  s, _ = trimCountryCode(s)
+
+  if len(s) == 13 && strings.HasPrefix(s, "86") {
+    s = s[2:]
+  }
+
+  if !mobilePattern.MatchString(s) {
+    return "", lc.error("err.mobile", lc.mask(tel))
+  }
+
+  return s, nil
+}
+
+// 规范化固定电话为“区号-号码[-分机号]”格式
+func (lc *Locale) NormalizeLandline(phone string) (string, error) {
+  s := landlineStripper.Replace(strings.TrimSpace(phone))
+  s = strings.TrimPrefix(s, "-")
+
+  // 国际格式下区号不带0，如+86 10 12345678
+  if rest, ok := trimCountryCode(s); ok {
+    s = "0" + strings.TrimPrefix(rest, "0")
+  }
+
+  // 无分隔符时，010、02x为三位区号，其余为四位
+  if !strings.Contains(s, "-") && len(s) >= 10 {
+    n := 4
+    if strings.HasPrefix(s, "01") || strings.HasPrefix(s, "02") {
+      n = 3
+    }
+    s = s[:n] + "-" + s[n:]
+  }
+
+  m := landlinePattern.FindStringSubmatch(s)
+  if m == nil {
+    return "", lc.error("err.landline", lc.mask(phone))
+  }
+
+  res := m[1] + "-" + m[2]
+  if m[3] != "" {
+    res += "-" + m[3]
+  }
+
+  return res, nil
+}
+
+// 当事人角色名称
+func (lc *Locale) partyLabel(role string) string {
+  switch role {
+  case "applicant", "respondent":
+    return lc.text("party." + role)
+  }
+
+  return role
+}
+
+// 字段的数据来源描述
+func (lc *Locale) sourceOf(key string, cells map[string]string) string {
+  if cell, ok := cells[key]; ok {
+    return lc.text("source.cell", cell)
+  }
+
+  role, name, _ := strings.Cut(key, ".")
+  return lc.text("source.config", "case.default" + strings.ToUpper(role[:1]) + role[1:] + "." + name)
+}
+
+// 规范化当事人的手机号与固定电话，问题指向数据来源（单元格或配置项）
+func (lc *Locale) NormalizePhones(per *PersonConfig, role string, cells map[string]string) []*Problem {
+  var problems []*Problem
+  if per == nil {
+    return append(problems, lc.problem(role, "", "PARTY_EMPTY", lc.partyLabel(role)))
+  }
+
+  if per.Tel != "" {
+    tel, err := lc.NormalizeMobile(per.Tel)
+    if err != nil {
+      key := role + ".tel"
+      problems = append(problems, lc.problem(key, cells[key], "PARTY_TEL_INVALID",
+                                             lc.partyLabel(role), lc.sourceOf(key, cells), err))
+    } else {
+      per.Tel = tel
+    }
+  }
+
+  if per.StaticPhone != "" {
+    phone, err := lc.NormalizeLandline(per.StaticPhone)
+    if err != nil {
+      key := role + ".staticPhone"
+      problems = append(problems, lc.problem(key, cells[key], "PARTY_STATIC_PHONE_INVALID",
+                                             lc.partyLabel(role), lc.sourceOf(key, cells), err))
+    } else {
+      per.StaticPhone = phone
+    }
+  }
+
+  return problems
+}
+
+// 检查当事人必填信息，path为字段路径前缀
+func (lc *Locale) CheckPerson(per *PersonConfig, path string) []*Problem {
+  var problems []*Problem
+  add := func(name string, code string, warning bool) {
+    p := lc.problem(path + "." + name, "", code)
+    p.Warning = warning
+    problems = append(problems, p)
+  }
+
+  if per == nil {
+    return append(problems, lc.problem(path, "", "PARTY_EMPTY", lc.text("party.either")))
+  }
+
+  if per.Type == "" {
+    add("type", "PARTY_TYPE_EMPTY", false)
+  }
+
+  if per.Name == "" {
+    add("name", "PARTY_NAME_EMPTY", false)
+  }
+
+  if per.Tel == "" {
+    add("tel", "PARTY_TEL_EMPTY", false)
+  }
+
+  if per.CredentialsType == "" {
+    add("credentialsType", "PARTY_CREDENTIALS_TYPE_EMPTY", false)
+  }
+
+  // 个别情况下允许
+  if per.IDCardNo == "" {
+    add("idCardNo", "PARTY_ID_CARD_EMPTY", true)
+  }
+
+  if per.Sex == "" {
+    add("sex", "PARTY_SEX_EMPTY", false)
+  }
+
+  if per.Birthday == "" {
+    add("birthday", "PARTY_BIRTHDAY_EMPTY", false)
+  }
+
+  if per.Nation == "" {
+    add("nation", "PARTY_NATION_EMPTY", false)
+  }
+
+  if per.AreaCode == "" {
+    add("areaCode", "PARTY_AREA_CODE_EMPTY", false)
+  }
+
+  if per.Address == "" {
+    add("address", "PARTY_ADDRESS_EMPTY", false)
+  }
+
+  return problems
+}
+
+// 规范当事人电话并检查申请人与被申请人信息，cells为字段对应的单元格（见FillRow，不是来自数据行时为空）
+func (lc *Locale) CheckParties(ca *CaseConfig, cells map[string]string) []*Problem {
+  var problems []*Problem
+  for _, role := range []string{ "applicant", "respondent" } {
+    per := ca.DefaultApplicant
+    if role == "respondent" {
+      per = ca.DefaultRespondent
+    }
+
+    problems = append(problems, lc.NormalizePhones(per, role, cells)...)
+    for _, p := range lc.CheckPerson(per, role) {
+      if p.Cell == "" {
+        p.Cell = cells[p.Path]
+      }
+      problems = append(problems, p)
+    }
+  }
+
+  return problems
+}
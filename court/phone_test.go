@@ -0,0 +1,68 @@
+package court
+
+import (
+  "testing"
+)
+
+func TestNormalizeMobile(t *testing.T) {
+  tests := []struct {
+    in   string
+    want string
+  }{
+    { "13800001234", "13800001234" },
+    { " 138 0000 1234 ", "13800001234" },
+    { "138-0000-1234", "13800001234" },
+    { "+86 13800001234", "13800001234" },
+    { "0086-13800001234", "13800001234" },
+    { "8613800001234", "13800001234" },
+    { "12800001234", "" },
+    { "1380000123", "" },
+    { "", "" },
+  }
+
+  for _, tt := range tests {
+    got, err := (*Locale)(nil).NormalizeMobile(tt.in)
+    if tt.want == "" {
+      if err == nil {
+        t.Errorf("NormalizeMobile(%q) = %q, want error", tt.in, got)
+      }
+      continue
+    }
+
+    if err != nil || got != tt.want {
+      t.Errorf("NormalizeMobile(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+    }
+  }
+}
+
+func TestNormalizeLandline(t *testing.T) {
+  tests := []struct {
+    in   string
+    want string
+  }{
+    { "010-12345678", "010-12345678" },
+    { "01012345678", "010-12345678" },
+    { "02112345678", "021-12345678" },
+    { "057112345678", "0571-12345678" },
+    { "(0571)1234567", "0571-1234567" },
+    { "0571-12345678-123", "0571-12345678-123" },
+    { "0571-12345678转123", "0571-12345678-123" },
+    { "+86 10 12345678", "010-12345678" },
+    { "12345678", "" },
+    { "abc", "" },
+  }
+
+  for _, tt := range tests {
+    got, err := (*Locale)(nil).NormalizeLandline(tt.in)
+    if tt.want == "" {
+      if err == nil {
+        t.Errorf("NormalizeLandline(%q) = %q, want error", tt.in, got)
+      }
+      continue
+    }
+
+    if err != nil || got != tt.want {
+      t.Errorf("NormalizeLandline(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+    }
+  }
+}
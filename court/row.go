@@ -0,0 +1,129 @@
+package court
+
+import (
+  "fmt"
+  "strings"
+)
+
+// 列号上限（XFD）
+const MAX_COLUMN = 16384
+
+// 列名格式错误（默认消息）
+var ErrColumnName = &Error{ Key: "err.columnName" }
+
+// 列名转换为列号，如 A -> 1、AB -> 28
+func ColumnNumber(col string) (int, error) {
+  col = strings.ToUpper(strings.TrimSpace(col))
+  if col == "" || len(col) > 3 {
+    return 0, ErrColumnName
+  }
+
+  n := 0
+  for _, c := range col {
+    if c < 'A' || c > 'Z' {
+      return 0, ErrColumnName
+    }
+    n = n * 26 + int(c - 'A') + 1
+  }
+
+  if n > MAX_COLUMN {
+    return 0, ErrColumnName
+  }
+
+  return n, nil
+}
+
+// 按字段名（同json标签）获取当事人字段
+func personField(per *PersonConfig, name string) *string {
+  switch name {
+  case "type":            return &per.Type
+  case "name":            return &per.Name
+  case "tel":             return &per.Tel
+  case "staticPhone":     return &per.StaticPhone
+  case "credentialsType": return &per.CredentialsType
+  case "idCardNo":        return &per.IDCardNo
+  case "sex":             return &per.Sex
+  case "birthday":        return &per.Birthday
+  case "nation":          return &per.Nation
+  case "areaCode":        return &per.AreaCode
+  case "address":         return &per.Address
+  }
+
+  return nil
+}
+
+// 解析列映射的键，如 "applicant.tel"，返回案件配置中对应的当事人字段
+func (lc *Locale) PartyField(ca *CaseConfig, key string) (*string, error) {
+  role, name, ok := strings.Cut(key, ".")
+  if !ok {
+    return nil, lc.error("err.mapperKey", key)
+  }
+
+  var per *PersonConfig
+  switch role {
+  case "applicant":
+    per = ca.DefaultApplicant
+  case "respondent":
+    per = ca.DefaultRespondent
+  default:
+    return nil, lc.error("err.mapperParty", key)
+  }
+
+  if per == nil {
+    return nil, lc.error("err.defaultParty", lc.partyLabel(role))
+  }
+
+  field := personField(per, name)
+  if field == nil {
+    return nil, lc.error("err.mapperField", key)
+  }
+
+  return field, nil
+}
+
+// 用一行数据填充案件配置，cols为当事人字段到列名的映射（如 "applicant.name": "A"），
+// line为该行的行号；返回各字段对应的单元格（如 "applicant.tel": "C17"）
+func (lc *Locale) FillRow(ca *CaseConfig, cols map[string]string, row []string, line int) (map[string]string, error) {
+  cells := map[string]string{}
+  for key, col := range cols {
+    n, err := ColumnNumber(col)
+    if err != nil {
+      return nil, lc.error("err.column", col, lc.error(ErrColumnName.Key))
+    }
+
+    field, err := lc.PartyField(ca, key)
+    if err != nil {
+      return nil, err
+    }
+
+    // 映射的单元格为空时保留默认值，姓名除外
+    v := ""
+    if n <= len(row) {
+      v = strings.TrimSpace(row[n - 1])
+    }
+
+    if v != "" || strings.HasSuffix(key, ".name") {
+      *field = v
+      cells[key] = fmt.Sprintf("%s%d", strings.ToUpper(col), line)
+    }
+  }
+
+  return cells, nil
+}
+
+// 由案件配置与一行数据生成请求体：填入该行数据，规范电话并检查当事人信息，
+// 有错误级别的问题时不生成请求体；不修改ca
+func (lc *Locale) BuildRowBody(ca *CaseConfig, cols map[string]string, row []string, line int) (*CaseBody, []*Problem, error) {
+  c := ca.Copy()
+  cells, err := lc.FillRow(c, cols, row, line)
+  if err != nil {
+    return nil, nil, err
+  }
+
+  problems := lc.CheckParties(c, cells)
+  if HasError(problems) {
+    return nil, problems, nil
+  }
+
+  return BuildCaseBody(c), problems, nil
+}
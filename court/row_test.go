@@ -0,0 +1,86 @@
+package court
+
+import (
+  "testing"
+)
+
+func TestColumnNumber(t *testing.T) {
+  tests := map[string]int{ "A": 1, "z": 26, "AB": 28, "XFD": MAX_COLUMN, "XFE": 0, "": 0, "A1": 0 }
+  for col, want := range tests {
+    got, err := ColumnNumber(col)
+    if got != want || (err == nil) != (want > 0) {
+      t.Errorf("ColumnNumber(%q) = %d, %v, want %d", col, got, err, want)
+    }
+  }
+}
+
+func TestBuildRowBody(t *testing.T) {
+  var lc *Locale
+  ca := testCase()
+  cols := map[string]string{
+    "applicant.name":       "A",
+    "respondent.name":      "B",
+    "applicant.tel":        "C",
+    "respondent.address":   "D",
+  }
+
+  body, problems, err := lc.BuildRowBody(ca, cols, []string{ "王五", "赵六", "+86 138 0000 1111", "" }, 7)
+  if err != nil || body == nil || HasError(problems) {
+    t.Fatalf("BuildRowBody = %v, %v, %v", body, problems, err)
+  }
+
+  app, res := body.ApplicantList[0], body.RespondentList[0]
+  if app.Name != "王五" || app.Tel != "13800001111" || res.Name != "赵六" {
+    t.Errorf("parties = %+v %+v", app, res)
+  }
+
+  // 空单元格保留默认值，且不修改传入的配置
+  if res.Address != "北京市西城区" || ca.DefaultApplicant.Name != "张三" {
+    t.Errorf("address %q, default applicant %q", res.Address, ca.DefaultApplicant.Name)
+  }
+
+  // 问题指向单元格，有错误时不生成请求体
+  body, problems, err = lc.BuildRowBody(ca, cols, []string{ "王五", "", "12345" }, 7)
+  if err != nil || body != nil {
+    t.Fatalf("BuildRowBody = %v, %v", body, err)
+  }
+
+  cells := map[string]string{}
+  for _, p := range problems {
+    cells[p.Code] = p.Cell
+  }
+  if cells["PARTY_TEL_INVALID"] != "C7" || cells["PARTY_NAME_EMPTY"] != "B7" {
+    t.Errorf("problems = %v", cells)
+  }
+
+  if _, _, err := lc.BuildRowBody(ca, map[string]string{ "agent.name": "E" }, nil, 7); err == nil {
+    t.Error("unknown party accepted")
+  }
+}
+
+// 不同Locale的消息与遮盖互不影响
+func TestLocale(t *testing.T) {
+  en := &Locale{
+    Translate: func(key string, args ...interface{}) string { return "en:" + key },
+    MaskPhone: func(phone string) string { return "***" },
+  }
+  zh := &Locale{}
+
+  ca := testCase()
+  cols := map[string]string{ "applicant.name": "A", "respondent.name": "B", "applicant.tel": "C" }
+  row := []string{ "王五", "赵六", "12345" }
+
+  _, problems, _ := en.BuildRowBody(ca, cols, row, 7)
+  if len(problems) == 0 || problems[0].Error() != "en:PARTY_TEL_INVALID" {
+    t.Errorf("en problems = %v", problems)
+  }
+
+  _, problems, _ = zh.BuildRowBody(ca, cols, row, 7)
+  if len(problems) == 0 || problems[0].Error() != "申请人手机号无法识别（单元格C7）：手机号格式错误（应为11位，以1开头）：\"12345\"" {
+    t.Errorf("zh problems = %v", problems)
+  }
+
+  if _, err := en.NormalizeMobile("12345"); err == nil || err.(*Error).Args[0] != "***" {
+    t.Errorf("masked err = %#v", err)
+  }
+}
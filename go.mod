@@ -1,10 +1,15 @@
-module example.com/m/v2
+module github.com/NataRich/auto-case
 
-go 1.20
+go 1.21
 
 require (
+	github.com/BurntSushi/toml v1.3.2
 	github.com/urfave/cli/v2 v2.25.7
 	github.com/xuri/excelize/v2 v2.7.1
+	golang.org/x/sys v0.15.0
+	golang.org/x/term v0.15.0
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
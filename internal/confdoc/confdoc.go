@@ -0,0 +1,78 @@
+// 从配置结构体源码的注释中提取字段说明，供配置文件schema与交互式向导使用
+package confdoc
+
+import (
+  "fmt"
+  "sort"
+  "bytes"
+  "strings"
+  "go/ast"
+  "go/parser"
+  "go/token"
+)
+
+// 结构体及字段说明（类型名 -> 字段名 -> 注释，空字段名为类型本身的注释）
+type Docs map[string]map[string]string
+
+// 解析源码并将其中结构体的说明加入docs，无法解析时忽略
+func Parse(src string, docs Docs) {
+  f, err := parser.ParseFile(token.NewFileSet(), "config.go", src, parser.ParseComments)
+  if err != nil {
+    return
+  }
+
+  for _, decl := range f.Decls {
+    gen, ok := decl.(*ast.GenDecl)
+    if !ok || gen.Tok != token.TYPE {
+      continue
+    }
+
+    for _, spec := range gen.Specs {
+      ts := spec.(*ast.TypeSpec)
+      st, ok := ts.Type.(*ast.StructType)
+      if !ok {
+        continue
+      }
+
+      fields := map[string]string{ "": strings.TrimSpace(gen.Doc.Text()) }
+      for _, field := range st.Fields.List {
+        for _, name := range field.Names {
+          fields[name.Name] = strings.TrimSpace(field.Doc.Text())
+        }
+      }
+      docs[ts.Name.Name] = fields
+    }
+  }
+}
+
+// 生成cmd/case中的courtDocs变量（见gen）
+func Render(docs Docs) []byte {
+  var buf bytes.Buffer
+  buf.WriteString("// Code generated by internal/confdoc/gen from court/config.go; DO NOT EDIT.\n\n")
+  buf.WriteString("package main\n\nimport (\n  \"github.com/NataRich/auto-case/internal/confdoc\"\n)\n\n")
+  buf.WriteString("// court包配置结构体的字段说明（见confDocs）\n")
+  buf.WriteString("var courtDocs = confdoc.Docs{\n")
+
+  types := make([]string, 0, len(docs))
+  for typ := range docs {
+    types = append(types, typ)
+  }
+  sort.Strings(types)
+
+  for _, typ := range types {
+    fields := make([]string, 0, len(docs[typ]))
+    for name := range docs[typ] {
+      fields = append(fields, name)
+    }
+    sort.Strings(fields)
+
+    fmt.Fprintf(&buf, "  %q: {\n", typ)
+    for _, name := range fields {
+      fmt.Fprintf(&buf, "    %q: %q,\n", name, docs[typ][name])
+    }
+    buf.WriteString("  },\n")
+  }
+
+  buf.WriteString("}\n")
+  return buf.Bytes()
+}
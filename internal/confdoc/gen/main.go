@@ -0,0 +1,30 @@
+// 由court包的配置结构体源码生成字段说明，用法：go run ./internal/confdoc/gen 源文件 输出文件
+package main
+
+import (
+  "os"
+  "fmt"
+
+  "github.com/NataRich/auto-case/internal/confdoc"
+)
+
+func main() {
+  if len(os.Args) != 3 {
+    fmt.Fprintln(os.Stderr, "usage: gen <court/config.go> <output.go>")
+    os.Exit(2)
+  }
+
+  src, err := os.ReadFile(os.Args[1])
+  if err != nil {
+    fmt.Fprintln(os.Stderr, err)
+    os.Exit(1)
+  }
+
+  docs := confdoc.Docs{}
+  confdoc.Parse(string(src), docs)
+
+  if err := os.WriteFile(os.Args[2], confdoc.Render(docs), 0644); err != nil {
+    fmt.Fprintln(os.Stderr, err)
+    os.Exit(1)
+  }
+}